@@ -3,6 +3,7 @@ package api
 import (
 	"net"
 	"slices"
+	"time"
 )
 
 const (
@@ -38,68 +39,351 @@ type SystemNetworkConfig struct {
 
 	Interfaces []SystemNetworkInterface `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
 	Bonds      []SystemNetworkBond      `json:"bonds,omitempty"      yaml:"bonds,omitempty"`
+	Teams      []SystemNetworkTeam      `json:"teams,omitempty"      yaml:"teams,omitempty"`
 	VLANs      []SystemNetworkVLAN      `json:"vlans,omitempty"      yaml:"vlans,omitempty"`
 	Wireguard  []SystemNetworkWireguard `json:"wireguard,omitempty"  yaml:"wireguard,omitempty"`
+	Tunnels    []SystemNetworkTunnel    `json:"tunnels,omitempty"    yaml:"tunnels,omitempty"`
+	VRFs       []SystemNetworkVRF       `json:"vrfs,omitempty"       yaml:"vrfs,omitempty"`
+	IPVLANs    []SystemNetworkIPVLAN    `json:"ipvlans,omitempty"    yaml:"ipvlans,omitempty"`
+
+	// Interface groups are expanded into concrete interfaces, one per matching physical NIC.
+	InterfaceGroups []SystemNetworkInterfaceGroup `json:"interface_groups,omitempty" yaml:"interface_groups,omitempty"`
+
+	// Devices matching any of these MAC addresses or name patterns are never managed by systemd-networkd.
+	Ignore []string `json:"ignore,omitempty" yaml:"ignore,omitempty"`
+
+	// Devices configured by another controller, only reported in the network state.
+	Observed []string `json:"observed,omitempty" yaml:"observed,omitempty"`
+
+	// Groups of redundant devices, where the network is considered online as soon as any member of each group is.
+	OnlineGroups []SystemNetworkOnlineGroup `json:"online_groups,omitempty" yaml:"online_groups,omitempty"`
+
+	// Name of a generated bridge (interface, bond, team or tunnel) used by applications as the default instance network.
+	PreferredBridge string `json:"preferred_bridge,omitempty" yaml:"preferred_bridge,omitempty"`
+
+	// If defined, IPv4 multicast is forwarded from the upstream device to downstream devices with IGMP members.
+	IGMPProxy *SystemNetworkIGMPProxy `json:"igmp_proxy,omitempty" yaml:"igmp_proxy,omitempty"`
+
+	// If defined, the network configuration can be replaced by the one served by the given endpoint.
+	RemoteSource *SystemNetworkRemoteSource `json:"remote_source,omitempty" yaml:"remote_source,omitempty"`
+
+	// If defined, network configuration outcomes and link state changes are pushed to the given endpoint.
+	Webhook *SystemNetworkWebhook `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+}
+
+// SystemNetworkWebhook defines an HTTPS endpoint notified of network changes.
+type SystemNetworkWebhook struct {
+	URL string `json:"url" yaml:"url"`
+
+	// Secret used to sign each request body, sent as an HMAC-SHA256 in the "X-IncusOS-Signature" header.
+	Secret string `json:"secret" yaml:"secret"`
+}
+
+// SystemNetworkIGMPProxy defines the devices taking part in IGMP proxying.
+type SystemNetworkIGMPProxy struct {
+	Interfaces []SystemNetworkIGMPProxyInterface `json:"interfaces" yaml:"interfaces"`
+}
+
+// SystemNetworkIGMPProxyInterface defines the role of a device in IGMP proxying.
+type SystemNetworkIGMPProxyInterface struct {
+	// Name of the interface, bond or VLAN.
+	Name string `json:"name" yaml:"name"`
+
+	// Either "upstream" or "downstream". Exactly one device must be upstream.
+	Role string `json:"role" yaml:"role"`
+
+	// Additional source networks for multicast received on the upstream device, outside of its own subnets.
+	AltNets []string `json:"altnets,omitempty" yaml:"altnets,omitempty"`
+}
+
+// SystemNetworkOnlineGroup defines a set of devices of which only one needs to be online.
+type SystemNetworkOnlineGroup struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Names of the member interfaces, bonds, teams, VLANs or IPVLANs.
+	Members []string `json:"members" yaml:"members"`
+}
+
+// SystemNetworkInterfaceGroup defines a common configuration applied to all matching physical interfaces.
+type SystemNetworkInterfaceGroup struct {
+	// Prefix used to name the generated interfaces, which are numbered in PCI address order.
+	Name string `json:"name" yaml:"name"`
+
+	// Match physical interfaces whose MAC address starts with the given prefix.
+	HwaddrPrefix string `json:"hwaddr_prefix,omitempty" yaml:"hwaddr_prefix,omitempty"`
+
+	// Match physical interfaces whose PCI address starts with the given prefix, for example "0000:03:".
+	PCIAddressPrefix string `json:"pci_address_prefix,omitempty" yaml:"pci_address_prefix,omitempty"`
+
+	// Template applied to each matching interface. Its name and hwaddr are ignored.
+	Interface SystemNetworkInterface `json:"interface" yaml:"interface"`
 }
 
 // SystemNetworkInterface contains information about a network interface.
 type SystemNetworkInterface struct {
-	Addresses         []string                    `json:"addresses,omitempty"           yaml:"addresses,omitempty"`
-	Ethernet          *SystemNetworkEthernet      `json:"ethernet,omitempty"            yaml:"ethernet,omitempty"`
-	FirewallRules     []SystemNetworkFirewallRule `json:"firewall_rules,omitempty"      yaml:"firewall_rules,omitempty"`
-	Hwaddr            string                      `json:"hwaddr"                        yaml:"hwaddr"`
-	LLDP              bool                        `json:"lldp,omitempty"                yaml:"lldp,omitempty"`
-	MTU               int                         `json:"mtu,omitempty"                 yaml:"mtu,omitempty"`
-	Name              string                      `json:"name"                          yaml:"name"`
-	RequiredForOnline string                      `json:"required_for_online,omitempty" yaml:"required_for_online,omitempty"`
-	Roles             []string                    `json:"roles,omitempty"               yaml:"roles,omitempty"`
-	Routes            []SystemNetworkRoute        `json:"routes,omitempty"              yaml:"routes,omitempty"`
-	StrictHwaddr      bool                        `json:"strict_hwaddr,omitempty"       yaml:"strict_hwaddr,omitempty"`
-	VLANTags          []int                       `json:"vlan_tags,omitempty"           yaml:"vlan_tags,omitempty"`
+	Addresses                 []string                       `json:"addresses,omitempty"                    yaml:"addresses,omitempty"`
+	AddressOptions            []SystemNetworkAddressOptions  `json:"address_options,omitempty"              yaml:"address_options,omitempty"`
+	AllMulticast              bool                           `json:"all_multicast,omitempty"                yaml:"all_multicast,omitempty"`
+	AlternativeNames          []string                       `json:"alternative_names,omitempty"            yaml:"alternative_names,omitempty"`
+	BridgeCost                int                            `json:"bridge_cost,omitempty"                  yaml:"bridge_cost,omitempty"`
+	BridgeFDB                 []SystemNetworkBridgeFDB       `json:"bridge_fdb,omitempty"                   yaml:"bridge_fdb,omitempty"`
+	BridgePriority            *int                           `json:"bridge_priority,omitempty"              yaml:"bridge_priority,omitempty"`
+	CarrierDelay              string                         `json:"carrier_delay,omitempty"                yaml:"carrier_delay,omitempty"`
+	DefaultPVID               *int                           `json:"default_pvid,omitempty"                 yaml:"default_pvid,omitempty"`
+	DefaultRouteSource        bool                           `json:"default_route_source,omitempty"         yaml:"default_route_source,omitempty"`
+	DHCPFallbackAddress       string                         `json:"dhcp_fallback_address,omitempty"        yaml:"dhcp_fallback_address,omitempty"`
+	DHCPUserClass             []string                       `json:"dhcp_user_class,omitempty"              yaml:"dhcp_user_class,omitempty"`
+	DHCPUseRoutes             *bool                          `json:"dhcp_use_routes,omitempty"              yaml:"dhcp_use_routes,omitempty"`
+	DHCPVendorClass           string                         `json:"dhcp_vendor_class,omitempty"            yaml:"dhcp_vendor_class,omitempty"`
+	DisableIPv6               bool                           `json:"disable_ipv6,omitempty"                 yaml:"disable_ipv6,omitempty"`
+	DNS                       *SystemNetworkLinkDNS          `json:"dns,omitempty"                          yaml:"dns,omitempty"`
+	Dot1X                     *SystemNetworkDot1X            `json:"dot1x,omitempty"                        yaml:"dot1x,omitempty"`
+	EgressRateLimit           string                         `json:"egress_rate_limit,omitempty"            yaml:"egress_rate_limit,omitempty"`
+	Ethernet                  *SystemNetworkEthernet         `json:"ethernet,omitempty"                     yaml:"ethernet,omitempty"`
+	ExtraOptions              map[string][]string            `json:"extra_options,omitempty"                yaml:"extra_options,omitempty"`
+	FirewallRules             []SystemNetworkFirewallRule    `json:"firewall_rules,omitempty"               yaml:"firewall_rules,omitempty"`
+	HardwareTimestamping      string                         `json:"hardware_timestamping,omitempty"        yaml:"hardware_timestamping,omitempty"`
+	Hwaddr                    string                         `json:"hwaddr"                                 yaml:"hwaddr"`
+	IngressRateLimit          string                         `json:"ingress_rate_limit,omitempty"           yaml:"ingress_rate_limit,omitempty"`
+	IPForwarding              string                         `json:"ip_forwarding,omitempty"                yaml:"ip_forwarding,omitempty"`
+	IPMasquerade              string                         `json:"ip_masquerade,omitempty"                yaml:"ip_masquerade,omitempty"`
+	IPv4LinkLocal             bool                           `json:"ipv4_link_local,omitempty"              yaml:"ipv4_link_local,omitempty"`
+	IPv4ProxyARP              bool                           `json:"ipv4_proxy_arp,omitempty"               yaml:"ipv4_proxy_arp,omitempty"`
+	IPv6AddressGenerationMode string                         `json:"ipv6_address_generation_mode,omitempty" yaml:"ipv6_address_generation_mode,omitempty"`
+	IPv6DAD                   *int                           `json:"ipv6_dad,omitempty"                     yaml:"ipv6_dad,omitempty"`
+	IPv6OnlyMode              bool                           `json:"ipv6_only_mode,omitempty"               yaml:"ipv6_only_mode,omitempty"`
+	IPv6ProxyNDP              bool                           `json:"ipv6_proxy_ndp,omitempty"               yaml:"ipv6_proxy_ndp,omitempty"`
+	IPv6ProxyNDPAddresses     []string                       `json:"ipv6_proxy_ndp_addresses,omitempty"     yaml:"ipv6_proxy_ndp_addresses,omitempty"`
+	KeepConfiguration         string                         `json:"keep_configuration,omitempty"           yaml:"keep_configuration,omitempty"`
+	LinkType                  string                         `json:"link_type,omitempty"                    yaml:"link_type,omitempty"`
+	LLDP                      bool                           `json:"lldp,omitempty"                         yaml:"lldp,omitempty"`
+	MACAddress                string                         `json:"mac_address,omitempty"                  yaml:"mac_address,omitempty"`
+	MACAddressPolicy          string                         `json:"mac_address_policy,omitempty"           yaml:"mac_address_policy,omitempty"`
+	MACLock                   bool                           `json:"mac_lock,omitempty"                     yaml:"mac_lock,omitempty"`
+	MACLockHwaddrs            []string                       `json:"mac_lock_hwaddrs,omitempty"             yaml:"mac_lock_hwaddrs,omitempty"`
+	Management                bool                           `json:"management,omitempty"                   yaml:"management,omitempty"`
+	MTU                       int                            `json:"mtu,omitempty"                          yaml:"mtu,omitempty"`
+	Name                      string                         `json:"name"                                   yaml:"name"`
+	Neighbors                 []SystemNetworkNeighbor        `json:"neighbors,omitempty"                    yaml:"neighbors,omitempty"`
+	NTPServers                []string                       `json:"ntp_servers,omitempty"                  yaml:"ntp_servers,omitempty"`
+	PrefixDelegation          *SystemNetworkPrefixDelegation `json:"prefix_delegation,omitempty"            yaml:"prefix_delegation,omitempty"`
+	Priority                  int                            `json:"priority,omitempty"                     yaml:"priority,omitempty"`
+	Promiscuous               bool                           `json:"promiscuous,omitempty"                  yaml:"promiscuous,omitempty"`
+	QoS                       *SystemNetworkQoS              `json:"qos,omitempty"                          yaml:"qos,omitempty"`
+	RequiredForOnline         string                         `json:"required_for_online,omitempty"          yaml:"required_for_online,omitempty"`
+	Roles                     []string                       `json:"roles,omitempty"                        yaml:"roles,omitempty"`
+	RouteMetric               int                            `json:"route_metric,omitempty"                 yaml:"route_metric,omitempty"`
+	Routes                    []SystemNetworkRoute           `json:"routes,omitempty"                       yaml:"routes,omitempty"`
+	SkipOnlineCheck           bool                           `json:"skip_online_check,omitempty"            yaml:"skip_online_check,omitempty"`
+	STP                       bool                           `json:"stp,omitempty"                          yaml:"stp,omitempty"`
+	StrictHwaddr              bool                           `json:"strict_hwaddr,omitempty"                yaml:"strict_hwaddr,omitempty"`
+	Sysctls                   map[string]string              `json:"sysctls,omitempty"                      yaml:"sysctls,omitempty"`
+	UdevRules                 []string                       `json:"udev_rules,omitempty"                   yaml:"udev_rules,omitempty"`
+	VethPeerDetached          bool                           `json:"veth_peer_detached,omitempty"           yaml:"veth_peer_detached,omitempty"`
+	VethPeerName              string                         `json:"veth_peer_name,omitempty"               yaml:"veth_peer_name,omitempty"`
+	VLANProtocol              string                         `json:"vlan_protocol,omitempty"                yaml:"vlan_protocol,omitempty"`
+	VLANTags                  []int                          `json:"vlan_tags,omitempty"                    yaml:"vlan_tags,omitempty"`
+	VRF                       string                         `json:"vrf,omitempty"                          yaml:"vrf,omitempty"`
+	VRRP                      []SystemNetworkVRRP            `json:"vrrp,omitempty"                         yaml:"vrrp,omitempty"`
 }
 
 // SystemNetworkBond contains information about a network bond.
 type SystemNetworkBond struct {
+	AdActorSystem             string                         `json:"ad_actor_system,omitempty"              yaml:"ad_actor_system,omitempty"`
+	AdActorSystemPriority     int                            `json:"ad_actor_system_priority,omitempty"     yaml:"ad_actor_system_priority,omitempty"`
+	Addresses                 []string                       `json:"addresses,omitempty"                    yaml:"addresses,omitempty"`
+	AddressOptions            []SystemNetworkAddressOptions  `json:"address_options,omitempty"              yaml:"address_options,omitempty"`
+	AdUserPortKey             int                            `json:"ad_user_port_key,omitempty"             yaml:"ad_user_port_key,omitempty"`
+	AllMulticast              bool                           `json:"all_multicast,omitempty"                yaml:"all_multicast,omitempty"`
+	BridgeCost                int                            `json:"bridge_cost,omitempty"                  yaml:"bridge_cost,omitempty"`
+	BridgeFDB                 []SystemNetworkBridgeFDB       `json:"bridge_fdb,omitempty"                   yaml:"bridge_fdb,omitempty"`
+	BridgePriority            *int                           `json:"bridge_priority,omitempty"              yaml:"bridge_priority,omitempty"`
+	CarrierDelay              string                         `json:"carrier_delay,omitempty"                yaml:"carrier_delay,omitempty"`
+	DefaultPVID               *int                           `json:"default_pvid,omitempty"                 yaml:"default_pvid,omitempty"`
+	DefaultRouteSource        bool                           `json:"default_route_source,omitempty"         yaml:"default_route_source,omitempty"`
+	DHCPFallbackAddress       string                         `json:"dhcp_fallback_address,omitempty"        yaml:"dhcp_fallback_address,omitempty"`
+	DHCPUserClass             []string                       `json:"dhcp_user_class,omitempty"              yaml:"dhcp_user_class,omitempty"`
+	DHCPUseRoutes             *bool                          `json:"dhcp_use_routes,omitempty"              yaml:"dhcp_use_routes,omitempty"`
+	DHCPVendorClass           string                         `json:"dhcp_vendor_class,omitempty"            yaml:"dhcp_vendor_class,omitempty"`
+	DisableIPv6               bool                           `json:"disable_ipv6,omitempty"                 yaml:"disable_ipv6,omitempty"`
+	DNS                       *SystemNetworkLinkDNS          `json:"dns,omitempty"                          yaml:"dns,omitempty"`
+	EgressRateLimit           string                         `json:"egress_rate_limit,omitempty"            yaml:"egress_rate_limit,omitempty"`
+	Ethernet                  *SystemNetworkEthernet         `json:"ethernet,omitempty"                     yaml:"ethernet,omitempty"`
+	ExtraOptions              map[string][]string            `json:"extra_options,omitempty"                yaml:"extra_options,omitempty"`
+	FirewallRules             []SystemNetworkFirewallRule    `json:"firewall_rules,omitempty"               yaml:"firewall_rules,omitempty"`
+	Hwaddr                    string                         `json:"hwaddr,omitempty"                       yaml:"hwaddr,omitempty"`
+	IngressRateLimit          string                         `json:"ingress_rate_limit,omitempty"           yaml:"ingress_rate_limit,omitempty"`
+	IPForwarding              string                         `json:"ip_forwarding,omitempty"                yaml:"ip_forwarding,omitempty"`
+	IPMasquerade              string                         `json:"ip_masquerade,omitempty"                yaml:"ip_masquerade,omitempty"`
+	IPv4LinkLocal             bool                           `json:"ipv4_link_local,omitempty"              yaml:"ipv4_link_local,omitempty"`
+	IPv4ProxyARP              bool                           `json:"ipv4_proxy_arp,omitempty"               yaml:"ipv4_proxy_arp,omitempty"`
+	IPv6AddressGenerationMode string                         `json:"ipv6_address_generation_mode,omitempty" yaml:"ipv6_address_generation_mode,omitempty"`
+	IPv6DAD                   *int                           `json:"ipv6_dad,omitempty"                     yaml:"ipv6_dad,omitempty"`
+	IPv6OnlyMode              bool                           `json:"ipv6_only_mode,omitempty"               yaml:"ipv6_only_mode,omitempty"`
+	IPv6ProxyNDP              bool                           `json:"ipv6_proxy_ndp,omitempty"               yaml:"ipv6_proxy_ndp,omitempty"`
+	IPv6ProxyNDPAddresses     []string                       `json:"ipv6_proxy_ndp_addresses,omitempty"     yaml:"ipv6_proxy_ndp_addresses,omitempty"`
+	KeepConfiguration         string                         `json:"keep_configuration,omitempty"           yaml:"keep_configuration,omitempty"`
+	LinkType                  string                         `json:"link_type,omitempty"                    yaml:"link_type,omitempty"`
+	LLDP                      bool                           `json:"lldp,omitempty"                         yaml:"lldp,omitempty"`
+	MACLock                   bool                           `json:"mac_lock,omitempty"                     yaml:"mac_lock,omitempty"`
+	MACLockHwaddrs            []string                       `json:"mac_lock_hwaddrs,omitempty"             yaml:"mac_lock_hwaddrs,omitempty"`
+	Members                   []string                       `json:"members,omitempty"                      yaml:"members,omitempty"`
+	MinLinks                  int                            `json:"min_links,omitempty"                    yaml:"min_links,omitempty"`
+	Mode                      string                         `json:"mode"                                   yaml:"mode"`
+	MTU                       int                            `json:"mtu,omitempty"                          yaml:"mtu,omitempty"`
+	Name                      string                         `json:"name"                                   yaml:"name"`
+	Neighbors                 []SystemNetworkNeighbor        `json:"neighbors,omitempty"                    yaml:"neighbors,omitempty"`
+	NTPServers                []string                       `json:"ntp_servers,omitempty"                  yaml:"ntp_servers,omitempty"`
+	PrefixDelegation          *SystemNetworkPrefixDelegation `json:"prefix_delegation,omitempty"            yaml:"prefix_delegation,omitempty"`
+	Priority                  int                            `json:"priority,omitempty"                     yaml:"priority,omitempty"`
+	Promiscuous               bool                           `json:"promiscuous,omitempty"                  yaml:"promiscuous,omitempty"`
+	QoS                       *SystemNetworkQoS              `json:"qos,omitempty"                          yaml:"qos,omitempty"`
+	RequiredForOnline         string                         `json:"required_for_online,omitempty"          yaml:"required_for_online,omitempty"`
+	Roles                     []string                       `json:"roles,omitempty"                        yaml:"roles,omitempty"`
+	RouteMetric               int                            `json:"route_metric,omitempty"                 yaml:"route_metric,omitempty"`
+	Routes                    []SystemNetworkRoute           `json:"routes,omitempty"                       yaml:"routes,omitempty"`
+	SkipOnlineCheck           bool                           `json:"skip_online_check,omitempty"            yaml:"skip_online_check,omitempty"`
+	STP                       bool                           `json:"stp,omitempty"                          yaml:"stp,omitempty"`
+	Sysctls                   map[string]string              `json:"sysctls,omitempty"                      yaml:"sysctls,omitempty"`
+	VLANProtocol              string                         `json:"vlan_protocol,omitempty"                yaml:"vlan_protocol,omitempty"`
+	VLANTags                  []int                          `json:"vlan_tags,omitempty"                    yaml:"vlan_tags,omitempty"`
+	VRF                       string                         `json:"vrf,omitempty"                          yaml:"vrf,omitempty"`
+	VRRP                      []SystemNetworkVRRP            `json:"vrrp,omitempty"                         yaml:"vrrp,omitempty"`
+}
+
+// SystemNetworkTeam contains information about a link aggregation managed by teamd.
+type SystemNetworkTeam struct {
 	Addresses         []string                    `json:"addresses,omitempty"           yaml:"addresses,omitempty"`
-	Ethernet          *SystemNetworkEthernet      `json:"ethernet,omitempty"            yaml:"ethernet,omitempty"`
 	FirewallRules     []SystemNetworkFirewallRule `json:"firewall_rules,omitempty"      yaml:"firewall_rules,omitempty"`
 	Hwaddr            string                      `json:"hwaddr,omitempty"              yaml:"hwaddr,omitempty"`
-	LLDP              bool                        `json:"lldp,omitempty"                yaml:"lldp,omitempty"`
+	KeepConfiguration string                      `json:"keep_configuration,omitempty"  yaml:"keep_configuration,omitempty"`
+	LinkType          string                      `json:"link_type,omitempty"           yaml:"link_type,omitempty"`
 	Members           []string                    `json:"members,omitempty"             yaml:"members,omitempty"`
 	Mode              string                      `json:"mode"                          yaml:"mode"`
 	MTU               int                         `json:"mtu,omitempty"                 yaml:"mtu,omitempty"`
 	Name              string                      `json:"name"                          yaml:"name"`
 	RequiredForOnline string                      `json:"required_for_online,omitempty" yaml:"required_for_online,omitempty"`
 	Roles             []string                    `json:"roles,omitempty"               yaml:"roles,omitempty"`
+	RouteMetric       int                         `json:"route_metric,omitempty"        yaml:"route_metric,omitempty"`
 	Routes            []SystemNetworkRoute        `json:"routes,omitempty"              yaml:"routes,omitempty"`
+	SkipOnlineCheck   bool                        `json:"skip_online_check,omitempty"   yaml:"skip_online_check,omitempty"`
 	VLANTags          []int                       `json:"vlan_tags,omitempty"           yaml:"vlan_tags,omitempty"`
 }
 
 // SystemNetworkVLAN contains information about a network vlan.
 type SystemNetworkVLAN struct {
-	Addresses         []string                    `json:"addresses,omitempty"           yaml:"addresses,omitempty"`
-	FirewallRules     []SystemNetworkFirewallRule `json:"firewall_rules,omitempty"      yaml:"firewall_rules,omitempty"`
-	ID                int                         `json:"id"                            yaml:"id"`
-	MTU               int                         `json:"mtu,omitempty"                 yaml:"mtu,omitempty"`
-	Name              string                      `json:"name"                          yaml:"name"`
-	Parent            string                      `json:"parent"                        yaml:"parent"`
-	RequiredForOnline string                      `json:"required_for_online,omitempty" yaml:"required_for_online,omitempty"`
-	Roles             []string                    `json:"roles,omitempty"               yaml:"roles,omitempty"`
-	Routes            []SystemNetworkRoute        `json:"routes,omitempty"              yaml:"routes,omitempty"`
+	Addresses                 []string                       `json:"addresses,omitempty"                    yaml:"addresses,omitempty"`
+	AddressOptions            []SystemNetworkAddressOptions  `json:"address_options,omitempty"              yaml:"address_options,omitempty"`
+	AllMulticast              bool                           `json:"all_multicast,omitempty"                yaml:"all_multicast,omitempty"`
+	DefaultRouteSource        bool                           `json:"default_route_source,omitempty"         yaml:"default_route_source,omitempty"`
+	DHCPFallbackAddress       string                         `json:"dhcp_fallback_address,omitempty"        yaml:"dhcp_fallback_address,omitempty"`
+	DHCPUserClass             []string                       `json:"dhcp_user_class,omitempty"              yaml:"dhcp_user_class,omitempty"`
+	DHCPUseRoutes             *bool                          `json:"dhcp_use_routes,omitempty"              yaml:"dhcp_use_routes,omitempty"`
+	DHCPVendorClass           string                         `json:"dhcp_vendor_class,omitempty"            yaml:"dhcp_vendor_class,omitempty"`
+	DisableIPv6               bool                           `json:"disable_ipv6,omitempty"                 yaml:"disable_ipv6,omitempty"`
+	DNS                       *SystemNetworkLinkDNS          `json:"dns,omitempty"                          yaml:"dns,omitempty"`
+	ExtraOptions              map[string][]string            `json:"extra_options,omitempty"                yaml:"extra_options,omitempty"`
+	FirewallRules             []SystemNetworkFirewallRule    `json:"firewall_rules,omitempty"               yaml:"firewall_rules,omitempty"`
+	Hwaddr                    string                         `json:"hwaddr,omitempty"                       yaml:"hwaddr,omitempty"`
+	ID                        int                            `json:"id"                                     yaml:"id"`
+	IPForwarding              string                         `json:"ip_forwarding,omitempty"                yaml:"ip_forwarding,omitempty"`
+	IPMasquerade              string                         `json:"ip_masquerade,omitempty"                yaml:"ip_masquerade,omitempty"`
+	IPv4LinkLocal             bool                           `json:"ipv4_link_local,omitempty"              yaml:"ipv4_link_local,omitempty"`
+	IPv4ProxyARP              bool                           `json:"ipv4_proxy_arp,omitempty"               yaml:"ipv4_proxy_arp,omitempty"`
+	IPv6AddressGenerationMode string                         `json:"ipv6_address_generation_mode,omitempty" yaml:"ipv6_address_generation_mode,omitempty"`
+	IPv6DAD                   *int                           `json:"ipv6_dad,omitempty"                     yaml:"ipv6_dad,omitempty"`
+	IPv6OnlyMode              bool                           `json:"ipv6_only_mode,omitempty"               yaml:"ipv6_only_mode,omitempty"`
+	IPv6ProxyNDP              bool                           `json:"ipv6_proxy_ndp,omitempty"               yaml:"ipv6_proxy_ndp,omitempty"`
+	IPv6ProxyNDPAddresses     []string                       `json:"ipv6_proxy_ndp_addresses,omitempty"     yaml:"ipv6_proxy_ndp_addresses,omitempty"`
+	KeepConfiguration         string                         `json:"keep_configuration,omitempty"           yaml:"keep_configuration,omitempty"`
+	LinkType                  string                         `json:"link_type,omitempty"                    yaml:"link_type,omitempty"`
+	MTU                       int                            `json:"mtu,omitempty"                          yaml:"mtu,omitempty"`
+	Name                      string                         `json:"name"                                   yaml:"name"`
+	Neighbors                 []SystemNetworkNeighbor        `json:"neighbors,omitempty"                    yaml:"neighbors,omitempty"`
+	NTPServers                []string                       `json:"ntp_servers,omitempty"                  yaml:"ntp_servers,omitempty"`
+	Parent                    string                         `json:"parent"                                 yaml:"parent"`
+	PrefixDelegation          *SystemNetworkPrefixDelegation `json:"prefix_delegation,omitempty"            yaml:"prefix_delegation,omitempty"`
+	Priority                  int                            `json:"priority,omitempty"                     yaml:"priority,omitempty"`
+	Promiscuous               bool                           `json:"promiscuous,omitempty"                  yaml:"promiscuous,omitempty"`
+	QoS                       *SystemNetworkQoS              `json:"qos,omitempty"                          yaml:"qos,omitempty"`
+	RequiredForOnline         string                         `json:"required_for_online,omitempty"          yaml:"required_for_online,omitempty"`
+	Roles                     []string                       `json:"roles,omitempty"                        yaml:"roles,omitempty"`
+	RouteMetric               int                            `json:"route_metric,omitempty"                 yaml:"route_metric,omitempty"`
+	Routes                    []SystemNetworkRoute           `json:"routes,omitempty"                       yaml:"routes,omitempty"`
+	SkipOnlineCheck           bool                           `json:"skip_online_check,omitempty"            yaml:"skip_online_check,omitempty"`
+	Sysctls                   map[string]string              `json:"sysctls,omitempty"                      yaml:"sysctls,omitempty"`
+	VRF                       string                         `json:"vrf,omitempty"                          yaml:"vrf,omitempty"`
+	VRRP                      []SystemNetworkVRRP            `json:"vrrp,omitempty"                         yaml:"vrrp,omitempty"`
+}
+
+// SystemNetworkIPVLAN contains information about an IPVLAN device.
+type SystemNetworkIPVLAN struct {
+	Addresses          []string                    `json:"addresses,omitempty"            yaml:"addresses,omitempty"`
+	DefaultRouteSource bool                        `json:"default_route_source,omitempty" yaml:"default_route_source,omitempty"`
+	ExtraOptions       map[string][]string         `json:"extra_options,omitempty"        yaml:"extra_options,omitempty"`
+	FirewallRules      []SystemNetworkFirewallRule `json:"firewall_rules,omitempty"       yaml:"firewall_rules,omitempty"`
+	KeepConfiguration  string                      `json:"keep_configuration,omitempty"   yaml:"keep_configuration,omitempty"`
+	LinkType           string                      `json:"link_type,omitempty"            yaml:"link_type,omitempty"`
+	Mode               string                      `json:"mode"                           yaml:"mode"`
+	MTU                int                         `json:"mtu,omitempty"                  yaml:"mtu,omitempty"`
+	Name               string                      `json:"name"                           yaml:"name"`
+	Parent             string                      `json:"parent"                         yaml:"parent"`
+	RequiredForOnline  string                      `json:"required_for_online,omitempty"  yaml:"required_for_online,omitempty"`
+	Roles              []string                    `json:"roles,omitempty"                yaml:"roles,omitempty"`
+	RouteMetric        int                         `json:"route_metric,omitempty"         yaml:"route_metric,omitempty"`
+	Routes             []SystemNetworkRoute        `json:"routes,omitempty"               yaml:"routes,omitempty"`
+	SkipOnlineCheck    bool                        `json:"skip_online_check,omitempty"    yaml:"skip_online_check,omitempty"`
 }
 
 // SystemNetworkEthernet contains Ethernet-specific configuration details (offloading and other features).
 type SystemNetworkEthernet struct {
+	CombinedChannels       int      `json:"combined_channels,omitempty"        yaml:"combined_channels,omitempty"`
 	DisableEnergyEfficient bool     `json:"disable_energy_efficient,omitempty" yaml:"disable_energy_efficient,omitempty"`
 	DisableGRO             bool     `json:"disable_gro,omitempty"              yaml:"disable_gro,omitempty"`
 	DisableGSO             bool     `json:"disable_gso,omitempty"              yaml:"disable_gso,omitempty"`
 	DisableIPv4TSO         bool     `json:"disable_ipv4_tso,omitempty"         yaml:"disable_ipv4_tso,omitempty"`
 	DisableIPv6TSO         bool     `json:"disable_ipv6_tso,omitempty"         yaml:"disable_ipv6_tso,omitempty"`
+	DisableRxFlowControl   bool     `json:"disable_rx_flow_control,omitempty"  yaml:"disable_rx_flow_control,omitempty"`
+	DisableTxFlowControl   bool     `json:"disable_tx_flow_control,omitempty"  yaml:"disable_tx_flow_control,omitempty"`
+	RxBufferSize           int      `json:"rx_buffer_size,omitempty"           yaml:"rx_buffer_size,omitempty"`
+	RxChannels             int      `json:"rx_channels,omitempty"              yaml:"rx_channels,omitempty"`
+	RxCoalesce             string   `json:"rx_coalesce,omitempty"              yaml:"rx_coalesce,omitempty"`
+	TxBufferSize           int      `json:"tx_buffer_size,omitempty"           yaml:"tx_buffer_size,omitempty"`
+	TxChannels             int      `json:"tx_channels,omitempty"              yaml:"tx_channels,omitempty"`
+	TxCoalesce             string   `json:"tx_coalesce,omitempty"              yaml:"tx_coalesce,omitempty"`
 	WakeOnLAN              bool     `json:"wakeonlan,omitempty"                yaml:"wakeonlan,omitempty"`
 	WakeOnLANModes         []string `json:"wakeonlan_modes,omitempty"          yaml:"wakeonlan_modes,omitempty"`
 	WakeOnLANPassword      string   `json:"wakeonlan_password,omitempty"       yaml:"wakeonlan_password,omitempty"`
 }
 
+// SystemNetworkDot1X contains 802.1X (EAP) authentication details for an interface.
+type SystemNetworkDot1X struct {
+	AnonymousIdentity string `json:"anonymous_identity,omitempty"  yaml:"anonymous_identity,omitempty"`
+	CACertificate     string `json:"ca_certificate,omitempty"      yaml:"ca_certificate,omitempty"`
+	ClientCertificate string `json:"client_certificate,omitempty"  yaml:"client_certificate,omitempty"`
+	ClientKey         string `json:"client_key,omitempty"          yaml:"client_key,omitempty"`
+	ClientKeyPassword string `json:"client_key_password,omitempty" yaml:"client_key_password,omitempty"`
+	EAPMethod         string `json:"eap_method"                    yaml:"eap_method"`
+	Identity          string `json:"identity"                      yaml:"identity"`
+	Password          string `json:"password,omitempty"            yaml:"password,omitempty"`
+	Phase2Method      string `json:"phase2_method,omitempty"       yaml:"phase2_method,omitempty"`
+}
+
+// SystemNetworkQoS contains traffic control settings (CAKE shaping or HTB classes) for a network device.
+type SystemNetworkQoS struct {
+	Bandwidth    string                  `json:"bandwidth,omitempty"     yaml:"bandwidth,omitempty"`
+	Classes      []SystemNetworkQoSClass `json:"classes,omitempty"       yaml:"classes,omitempty"`
+	DefaultClass int                     `json:"default_class,omitempty" yaml:"default_class,omitempty"`
+}
+
+// SystemNetworkQoSClass defines a HTB traffic class.
+type SystemNetworkQoSClass struct {
+	CeilRate string `json:"ceil_rate,omitempty" yaml:"ceil_rate,omitempty"`
+	ID       int    `json:"id"                  yaml:"id"`
+	Priority int    `json:"priority,omitempty"  yaml:"priority,omitempty"`
+	Rate     string `json:"rate"                yaml:"rate"`
+}
+
 // SystemNetworkFirewallRule defines a firewall rule.
 type SystemNetworkFirewallRule struct {
 	Action   string `json:"action"             yaml:"action"`
@@ -111,6 +395,7 @@ type SystemNetworkFirewallRule struct {
 // SystemNetworkWireguard contains information about a wireguard interface.
 type SystemNetworkWireguard struct {
 	Addresses         []string                     `json:"addresses,omitempty"           yaml:"addresses,omitempty"`
+	ExtraOptions      map[string][]string          `json:"extra_options,omitempty"       yaml:"extra_options,omitempty"`
 	FirewallRules     []SystemNetworkFirewallRule  `json:"firewall_rules,omitempty"      yaml:"firewall_rules,omitempty"`
 	MTU               int                          `json:"mtu,omitempty"                 yaml:"mtu,omitempty"`
 	Name              string                       `json:"name"                          yaml:"name"`
@@ -120,6 +405,7 @@ type SystemNetworkWireguard struct {
 	RequiredForOnline string                       `json:"required_for_online,omitempty" yaml:"required_for_online,omitempty"`
 	Roles             []string                     `json:"roles,omitempty"               yaml:"roles,omitempty"`
 	Routes            []SystemNetworkRoute         `json:"routes,omitempty"              yaml:"routes,omitempty"`
+	TCPMSSClamp       bool                         `json:"tcp_mss_clamp,omitempty"       yaml:"tcp_mss_clamp,omitempty"`
 }
 
 // SystemNetworkWireguardPeer defines wireguard peer.
@@ -131,10 +417,115 @@ type SystemNetworkWireguardPeer struct {
 	PublicKey           string   `json:"public_key"                     yaml:"public_key"`
 }
 
+// SystemNetworkTunnel contains information about a GRE, GRETAP or SIT tunnel.
+type SystemNetworkTunnel struct {
+	Addresses         []string                    `json:"addresses,omitempty"           yaml:"addresses,omitempty"`
+	ExtraOptions      map[string][]string         `json:"extra_options,omitempty"       yaml:"extra_options,omitempty"`
+	FirewallRules     []SystemNetworkFirewallRule `json:"firewall_rules,omitempty"      yaml:"firewall_rules,omitempty"`
+	Key               int                         `json:"key,omitempty"                 yaml:"key,omitempty"`
+	Kind              string                      `json:"kind"                          yaml:"kind"`
+	Local             string                      `json:"local,omitempty"               yaml:"local,omitempty"`
+	MTU               int                         `json:"mtu,omitempty"                 yaml:"mtu,omitempty"`
+	Name              string                      `json:"name"                          yaml:"name"`
+	Remote            string                      `json:"remote"                        yaml:"remote"`
+	RequiredForOnline string                      `json:"required_for_online,omitempty" yaml:"required_for_online,omitempty"`
+	Roles             []string                    `json:"roles,omitempty"               yaml:"roles,omitempty"`
+	Routes            []SystemNetworkRoute        `json:"routes,omitempty"              yaml:"routes,omitempty"`
+	TCPMSSClamp       bool                        `json:"tcp_mss_clamp,omitempty"       yaml:"tcp_mss_clamp,omitempty"`
+	TTL               int                         `json:"ttl,omitempty"                 yaml:"ttl,omitempty"`
+}
+
+// SystemNetworkAddressOptions defines additional options for one of a device's static addresses.
+type SystemNetworkAddressOptions struct {
+	// The address the options apply to, which must be one of the device's static addresses.
+	Address string `json:"address" yaml:"address"`
+
+	HomeAddress bool   `json:"home_address,omitempty" yaml:"home_address,omitempty"`
+	Label       string `json:"label,omitempty"        yaml:"label,omitempty"`
+
+	// One of "forever", "infinity" or "0". Setting it to "0" deprecates the address.
+	PreferredLifetime string `json:"preferred_lifetime,omitempty" yaml:"preferred_lifetime,omitempty"`
+
+	// One of "global", "link", "host" or a number between 0 and 255.
+	Scope string `json:"scope,omitempty" yaml:"scope,omitempty"`
+}
+
+// SystemNetworkNeighbor defines a static ARP/ND entry.
+type SystemNetworkNeighbor struct {
+	Address string `json:"address" yaml:"address"`
+	Hwaddr  string `json:"hwaddr"  yaml:"hwaddr"`
+
+	// If true, the entry never expires. Otherwise it's seeded as reachable and then aged out by the kernel.
+	Permanent bool `json:"permanent,omitempty" yaml:"permanent,omitempty"`
+}
+
+// SystemNetworkBridgeFDB defines a static forwarding entry in a bridge, pointing a MAC address at one of its ports.
+type SystemNetworkBridgeFDB struct {
+	Hwaddr string `json:"hwaddr"         yaml:"hwaddr"`
+	VLAN   int    `json:"vlan,omitempty" yaml:"vlan,omitempty"`
+
+	// Either "uplink" (the physical interface or bond, default) or "host" (the host side of the bridge).
+	Destination string `json:"destination,omitempty" yaml:"destination,omitempty"`
+}
+
+// SystemNetworkVRRP defines a VRRP virtual router, sharing one or more virtual addresses with other hosts.
+type SystemNetworkVRRP struct {
+	VRID             int      `json:"vrid"               yaml:"vrid"`
+	Priority         int      `json:"priority,omitempty" yaml:"priority,omitempty"`
+	VirtualAddresses []string `json:"virtual_addresses"  yaml:"virtual_addresses"`
+
+	// Interval between advertisements, in seconds. Defaults to one second.
+	AdvertInterval int `json:"advert_interval,omitempty" yaml:"advert_interval,omitempty"`
+
+	// Simple password authentication, only supported with IPv4 virtual addresses.
+	AuthPassword string `json:"auth_password,omitempty" yaml:"auth_password,omitempty"`
+}
+
 // SystemNetworkRoute defines a route.
 type SystemNetworkRoute struct {
 	To  string `json:"to"  yaml:"to"`
 	Via string `json:"via" yaml:"via"`
+
+	// If defined, a multipath route is created across the listed gateways instead of using Via.
+	Gateways []SystemNetworkRouteGateway `json:"gateways,omitempty" yaml:"gateways,omitempty"`
+
+	// If defined, the routing table the route is added to.
+	Table int `json:"table,omitempty" yaml:"table,omitempty"`
+
+	// If defined, the preferred source address used for traffic matching the route.
+	// Must be one of the device's configured addresses.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+
+	// If defined, the path MTU used for traffic matching the route.
+	// Must not exceed the device's MTU.
+	MTU int `json:"mtu,omitempty" yaml:"mtu,omitempty"`
+
+	// If defined, the next-hop is monitored using BFD and the route is withdrawn while the session is down.
+	BFD *SystemNetworkRouteBFD `json:"bfd,omitempty" yaml:"bfd,omitempty"`
+}
+
+// SystemNetworkRouteBFD defines the BFD session parameters used to monitor a route's next-hop.
+type SystemNetworkRouteBFD struct {
+	// Number of missed packets before the session is considered down (defaults to 3).
+	DetectMultiplier int `json:"detect_multiplier,omitempty" yaml:"detect_multiplier,omitempty"`
+
+	// Minimum receive interval in milliseconds (defaults to 300).
+	ReceiveInterval int `json:"receive_interval,omitempty" yaml:"receive_interval,omitempty"`
+
+	// Minimum transmit interval in milliseconds (defaults to 300).
+	TransmitInterval int `json:"transmit_interval,omitempty" yaml:"transmit_interval,omitempty"`
+}
+
+// SystemNetworkRouteGateway defines a weighted next-hop of a multipath route.
+type SystemNetworkRouteGateway struct {
+	Address string `json:"address"          yaml:"address"`
+	Weight  int    `json:"weight,omitempty" yaml:"weight,omitempty"`
+}
+
+// SystemNetworkVRF defines a virtual routing and forwarding device with its own routing table.
+type SystemNetworkVRF struct {
+	Name  string `json:"name"  yaml:"name"`
+	Table int    `json:"table" yaml:"table"`
 }
 
 // SystemNetworkDNS defines DNS configuration options.
@@ -144,12 +535,67 @@ type SystemNetworkDNS struct {
 	Nameservers   []string `json:"nameservers,omitempty"    yaml:"nameservers,omitempty"`
 	SearchDomains []string `json:"search_domains,omitempty" yaml:"search_domains,omitempty"`
 	DNSOverTLS    bool     `json:"dns_over_tls,omitempty"   yaml:"dns_over_tls,omitempty"`
+
+	// Domains whose queries are routed to these nameservers, without being added to the search path.
+	RoutingDomains []string `json:"routing_domains,omitempty" yaml:"routing_domains,omitempty"`
+
+	// Global systemd-resolved options.
+	Cache              string `json:"cache,omitempty"                yaml:"cache,omitempty"`
+	CacheFromLocalhost bool   `json:"cache_from_localhost,omitempty" yaml:"cache_from_localhost,omitempty"`
+	LLMNR              string `json:"llmnr,omitempty"                yaml:"llmnr,omitempty"`
+	MulticastDNS       string `json:"multicast_dns,omitempty"        yaml:"multicast_dns,omitempty"`
+
+	// Static hostname to IP address mappings added to /etc/hosts, resolvable without a DNS server.
+	StaticHosts map[string]string `json:"static_hosts,omitempty" yaml:"static_hosts,omitempty"`
+}
+
+// SystemNetworkLinkDNS defines DNS options that override the global DNS configuration for a single device.
+type SystemNetworkLinkDNS struct {
+	Nameservers   []string `json:"nameservers,omitempty"    yaml:"nameservers,omitempty"`
+	SearchDomains []string `json:"search_domains,omitempty" yaml:"search_domains,omitempty"`
+
+	// Domains whose queries are routed to these nameservers, without being added to the search path.
+	RoutingDomains []string `json:"routing_domains,omitempty" yaml:"routing_domains,omitempty"`
+}
+
+// SystemNetworkPrefixDelegation defines the DHCPv6 prefix delegation (DHCPv6-PD) settings of a device.
+type SystemNetworkPrefixDelegation struct {
+	// Either "uplink" (the device requesting a prefix via DHCPv6) or "downstream" (a device assigned a sub-prefix).
+	Mode string `json:"mode" yaml:"mode"`
+
+	// For the uplink, the length of the prefix to request from the DHCPv6 server (e.g. 56).
+	PrefixLength int `json:"prefix_length,omitempty" yaml:"prefix_length,omitempty"`
+
+	// For downstream devices, the subnet ID used to select the sub-prefix from the delegated prefix.
+	SubnetID *int `json:"subnet_id,omitempty" yaml:"subnet_id,omitempty"`
+
+	// For downstream devices, additional routes announced in router advertisements.
+	AdvertisedRoutes []SystemNetworkRARoute `json:"advertised_routes,omitempty" yaml:"advertised_routes,omitempty"`
+
+	// For downstream devices, the router preference announced in router advertisements ("high", "medium" or "low").
+	RouterPreference string `json:"router_preference,omitempty" yaml:"router_preference,omitempty"`
+
+	// For downstream devices, the hop limit announced in router advertisements (1-255), 0 leaving it unspecified.
+	HopLimit int `json:"hop_limit,omitempty" yaml:"hop_limit,omitempty"`
+}
+
+// SystemNetworkRARoute defines a route announced in IPv6 router advertisements.
+type SystemNetworkRARoute struct {
+	// The IPv6 prefix of the route, in CIDR notation.
+	Route string `json:"route" yaml:"route"`
+
+	// How long the route remains valid (e.g. "30m"), defaults to one week.
+	Lifetime string `json:"lifetime,omitempty" yaml:"lifetime,omitempty"`
 }
 
 // SystemNetworkTime defines various time related configuration options (NTP servers, timezone, etc).
 type SystemNetworkTime struct {
-	NTPServers []string `json:"ntp_servers,omitempty" yaml:"ntp_servers,omitempty"`
-	Timezone   string   `json:"timezone,omitempty"    yaml:"timezone,omitempty"`
+	IgnoreDHCP      bool     `json:"ignore_dhcp,omitempty"       yaml:"ignore_dhcp,omitempty"`
+	NTPServers      []string `json:"ntp_servers,omitempty"       yaml:"ntp_servers,omitempty"`
+	PollIntervalMax string   `json:"poll_interval_max,omitempty" yaml:"poll_interval_max,omitempty"`
+	PollIntervalMin string   `json:"poll_interval_min,omitempty" yaml:"poll_interval_min,omitempty"`
+	RootDistanceMax string   `json:"root_distance_max,omitempty" yaml:"root_distance_max,omitempty"`
+	Timezone        string   `json:"timezone,omitempty"          yaml:"timezone,omitempty"`
 }
 
 // SystemNetworkProxy defines proxy configuration.
@@ -176,8 +622,16 @@ type SystemNetworkProxyRule struct {
 
 // SystemNetworkState holds information about the current network state.
 type SystemNetworkState struct {
-	Interfaces             map[string]SystemNetworkInterfaceState `json:"interfaces"               yaml:"interfaces"`
-	ConfigurationInProcess bool                                   `json:"configuration_in_process" yaml:"configuration_in_process"`
+	Interfaces             map[string]SystemNetworkInterfaceState `json:"interfaces"                    yaml:"interfaces"`
+	ConfigurationApplied   bool                                   `json:"configuration_applied"         yaml:"configuration_applied"`
+	ConfigurationError     string                                 `json:"configuration_error,omitempty" yaml:"configuration_error,omitempty"`
+	ConfigurationInProcess bool                                   `json:"configuration_in_process"      yaml:"configuration_in_process"`
+
+	// True if some required devices never came online during the last configuration.
+	Degraded bool `json:"degraded" yaml:"degraded"`
+
+	// The required devices that never came online during the last configuration.
+	DegradedDevices []string `json:"degraded_devices,omitempty" yaml:"degraded_devices,omitempty"`
 }
 
 // GetInterfaceNamesByRole returns a slice of interface names that have the given role applied to them.
@@ -262,6 +716,73 @@ type SystemNetworkInterfaceState struct {
 	Wireguard *SystemNetworkWireguardState           `json:"wireguard,omitempty" yaml:"wireguard,omitempty"`
 }
 
+// SystemNetworkReconciliation describes how the live state of a device differs from the applied configuration.
+type SystemNetworkReconciliation struct {
+	Device           string   `json:"device"                      yaml:"device"`
+	ExpectedMTU      int      `json:"expected_mtu,omitempty"      yaml:"expected_mtu,omitempty"`
+	ExtraAddresses   []string `json:"extra_addresses,omitempty"   yaml:"extra_addresses,omitempty"`
+	InSync           bool     `json:"in_sync"                     yaml:"in_sync"`
+	Missing          bool     `json:"missing,omitempty"           yaml:"missing,omitempty"`
+	MissingAddresses []string `json:"missing_addresses,omitempty" yaml:"missing_addresses,omitempty"`
+	MissingRoutes    []string `json:"missing_routes,omitempty"    yaml:"missing_routes,omitempty"`
+	MTU              int      `json:"mtu,omitempty"               yaml:"mtu,omitempty"`
+}
+
+// SystemNetworkEvent describes a live change in the state of a network device.
+type SystemNetworkEvent struct {
+	Address   string    `json:"address,omitempty" yaml:"address,omitempty"`
+	Device    string    `json:"device"            yaml:"device"`
+	State     string    `json:"state,omitempty"   yaml:"state,omitempty"`
+	Timestamp time.Time `json:"timestamp"         yaml:"timestamp"`
+	Type      string    `json:"type"              yaml:"type"`
+}
+
+// SystemNetworkWebhookEvent is the body sent to the network webhook, along with the current network state.
+type SystemNetworkWebhookEvent struct {
+	Error     string              `json:"error,omitempty" yaml:"error,omitempty"`
+	Event     *SystemNetworkEvent `json:"event,omitempty" yaml:"event,omitempty"`
+	State     SystemNetworkState  `json:"state"           yaml:"state"`
+	Timestamp time.Time           `json:"timestamp"       yaml:"timestamp"`
+	Type      string              `json:"type"            yaml:"type"`
+}
+
+// SystemNetworkValidationError describes a validation failure of a specific device field.
+type SystemNetworkValidationError struct {
+	Code    string `json:"code"    yaml:"code"`
+	Device  string `json:"device"  yaml:"device"`
+	Field   string `json:"field"   yaml:"field"`
+	Kind    string `json:"kind"    yaml:"kind"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// SystemNetworkRemoteSource defines an HTTPS endpoint serving a network configuration as JSON.
+type SystemNetworkRemoteSource struct {
+	URL string `json:"url" yaml:"url"`
+
+	// Optional token sent in the "Authorization: Bearer" header.
+	BearerToken string `json:"bearer_token,omitempty" yaml:"bearer_token,omitempty"`
+
+	// Optional PEM encoded client certificate and key used to authenticate to the server.
+	ClientCertificate string `json:"client_certificate,omitempty" yaml:"client_certificate,omitempty"`
+	ClientKey         string `json:"client_key,omitempty"         yaml:"client_key,omitempty"`
+
+	// Optional PEM encoded certificate to trust for a server using a self-signed certificate.
+	ServerCertificate string `json:"server_certificate,omitempty" yaml:"server_certificate,omitempty"`
+}
+
+// SystemNetworkPhysicalInterface describes a physical Ethernet interface present on the system.
+// The name and hwaddr fields match those of SystemNetworkInterface.
+type SystemNetworkPhysicalInterface struct {
+	Carrier    bool   `json:"carrier"               yaml:"carrier"`
+	Driver     string `json:"driver,omitempty"      yaml:"driver,omitempty"`
+	Hwaddr     string `json:"hwaddr"                yaml:"hwaddr"`
+	Management bool   `json:"management,omitempty"  yaml:"management,omitempty"`
+	Name       string `json:"name"                  yaml:"name"`
+	PCIAddress string `json:"pci_address,omitempty" yaml:"pci_address,omitempty"`
+	Path       string `json:"path,omitempty"        yaml:"path,omitempty"`
+	Speed      string `json:"speed,omitempty"       yaml:"speed,omitempty"`
+}
+
 // SystemNetworkInterfaceStats holds RX/TX stats for an interface.
 type SystemNetworkInterfaceStats struct {
 	RXBytes  int `json:"rx_bytes"  yaml:"rx_bytes"`
@@ -0,0 +1,6 @@
+package api
+
+// SystemPowerAction defines a struct that takes an optional reason for a reboot or power off.
+type SystemPowerAction struct {
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
@@ -0,0 +1,6 @@
+package api
+
+// SystemTimezone defines a struct to hold the system's timezone.
+type SystemTimezone struct {
+	Timezone string `json:"timezone" yaml:"timezone"`
+}
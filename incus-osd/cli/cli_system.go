@@ -137,7 +137,15 @@ func (c *cmdAdminOSSystem) command() *cobra.Command {
 					endpoint:    "system/network",
 				}
 
-				return []*cobra.Command{networkConfirmCmd.command(), flushDNSCmd.command()}
+				// Re-apply current network configuration.
+				networkReapplyCmd := cmdGenericRun{
+					os:          c.os,
+					action:      "reapply",
+					description: "Re-apply the current network configuration",
+					endpoint:    "system/network",
+				}
+
+				return []*cobra.Command{networkConfirmCmd.command(), flushDNSCmd.command(), networkReapplyCmd.command()}
 			},
 		},
 		{
@@ -257,6 +265,11 @@ func (c *cmdAdminOSSystem) command() *cobra.Command {
 				return []*cobra.Command{createVolumeCmd.command(), deletePoolCmd.command(), deleteVolumeCmd.command(), encryptDriveCmd.command(), importEncryptedDriveCmd.command(), importPoolCmd.command(), wipeDriveCmd.command(), scrubPoolCmd.command()}
 			},
 		},
+		{
+			name:        "timezone",
+			description: "System timezone",
+			isWritable:  true,
+		},
 		{
 			name:        "update",
 			description: "Update configuration",
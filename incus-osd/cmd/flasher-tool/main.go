@@ -439,7 +439,8 @@ func configureNetworkSeed(ctx context.Context) error {
 		return nil
 	}
 
-	err = systemd.ValidateNetworkConfiguration(&newSeed.SystemNetworkConfig, false)
+	// The seed is validated away from the target system, so only run the checks that don't depend on it.
+	err = systemd.LintNetworkConfiguration(&newSeed.SystemNetworkConfig)
 	if err != nil {
 		slog.ErrorContext(ctx, err.Error())
 
@@ -17,6 +17,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	ocapi "github.com/FuturFusion/operations-center/shared/api"
@@ -721,6 +722,16 @@ func startup(ctx context.Context, s *state.State) error { //nolint:revive
 		if err != nil && !seed.IsMissing(err) {
 			return err
 		}
+
+		// If the seed points to a remote source, bring up a minimal network to fetch the actual configuration.
+		if s.System.Network.Config != nil && s.System.Network.Config.RemoteSource != nil {
+			networkCfg, err := systemd.FetchInitialNetworkConfiguration(ctx, *s.System.Network.Config.RemoteSource)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to fetch the remote network configuration, using the seed one", "err", err)
+			} else {
+				s.System.Network.Config = networkCfg
+			}
+		}
 	}
 
 	// Record the state of auto-unlocked LUKS devices. With some TPMs this can be slow, so cache the
@@ -747,7 +758,7 @@ func startup(ctx context.Context, s *state.State) error { //nolint:revive
 		return err
 	}
 
-	err = systemd.ApplyNetworkConfiguration(ctx, s, s.System.Network.Config, 30*time.Second, s.OS.SuccessfulBoot, providers.Notify, delayInitialUpdateCheck)
+	err = systemd.ApplyNetworkConfiguration(ctx, s, s.System.Network.Config, 30*time.Second, s.OS.SuccessfulBoot, false, providers.Notify, delayInitialUpdateCheck, applications.GetNetworkChangeHooks(s))
 	if err != nil {
 		return err
 	}
@@ -874,9 +885,17 @@ func startup(ctx context.Context, s *state.State) error { //nolint:revive
 	chSignal := make(chan os.Signal, 1)
 	signal.Notify(chSignal, unix.SIGTERM)
 
+	chReload := make(chan os.Signal, 1)
+	signal.Notify(chReload, unix.SIGHUP)
+
 	go func() {
 		action := "exit"
 
+		// Network reloads run in the background, so they can't delay a shutdown, and get cancelled by it.
+		reloadCtx, reloadCancel := context.WithCancel(ctx)
+
+		var muReload sync.Mutex
+
 		// Action handler.
 	waitSignal:
 		select {
@@ -884,10 +903,14 @@ func startup(ctx context.Context, s *state.State) error { //nolint:revive
 		case <-s.TriggerReboot:
 			action = "reboot"
 
+			slog.InfoContext(ctx, "Rebooting the system", "reason", s.OS.LastShutdownReason)
+
 			_ = providers.Notify(ctx, s, ocapi.ServerSelfUpdateCauseSystemRebootTriggered)
 		case <-s.TriggerShutdown:
 			action = "shutdown"
 
+			slog.InfoContext(ctx, "Powering off the system", "reason", s.OS.LastShutdownReason)
+
 			_ = providers.Notify(ctx, s, ocapi.ServerSelfUpdateCauseShutdownTriggered)
 		case <-s.TriggerSuspend:
 			action = "suspend"
@@ -906,9 +929,30 @@ func startup(ctx context.Context, s *state.State) error { //nolint:revive
 				slog.ErrorContext(ctx, "Failed to start fallback HTTPS listener", "err", err)
 			}
 
+			goto waitSignal
+		case <-chReload:
+			if !muReload.TryLock() {
+				slog.WarnContext(ctx, "Network configuration reload already in progress")
+
+				goto waitSignal
+			}
+
+			go func() {
+				defer muReload.Unlock()
+
+				err := reloadNetworkConfiguration(reloadCtx, s)
+				if err != nil {
+					slog.ErrorContext(ctx, "Failed to reload network configuration", "err", err)
+				} else {
+					slog.InfoContext(ctx, "Network configuration reloaded")
+				}
+			}()
+
 			goto waitSignal
 		}
 
+		reloadCancel()
+
 		err := shutdown(ctx, s)
 		if err != nil {
 			slog.ErrorContext(ctx, "Failed shutdown sequence", "err", err)
@@ -1169,6 +1213,39 @@ func configureIncusAgent(ctx context.Context, s *state.State) error {
 	return nil
 }
 
+// reloadNetworkConfiguration re-reads the network configuration from the on-disk state and applies it.
+// If the new configuration fails validation, or a prior one is pending confirmation, the running configuration
+// is left untouched.
+func reloadNetworkConfiguration(ctx context.Context, s *state.State) error {
+	body, err := os.ReadFile(filepath.Join(varPath, "state.txt"))
+	if err != nil {
+		return err
+	}
+
+	var diskState state.State
+
+	err = state.Decode(body, nil, &diskState)
+	if err != nil {
+		return err
+	}
+
+	networkCfg := diskState.System.Network.Config
+
+	err = systemd.ValidateNetworkConfiguration(networkCfg, false)
+	if err != nil {
+		return err
+	}
+
+	confirmationTimeout, err := rest.ParseConfirmationTimeout(networkCfg)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Reloading network configuration")
+
+	return rest.ApplyNetworkConfiguration(ctx, s, networkCfg, confirmationTimeout)
+}
+
 func startFallbackListener(ctx context.Context, s *state.State) error {
 	// Get the primary application, requiring that it be initialized.
 	app, err := applications.GetPrimary(ctx, s, true)
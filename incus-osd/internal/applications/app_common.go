@@ -84,6 +84,11 @@ func (*common) GetServerCertificate() (*tls.Certificate, error) {
 	return nil, errors.New("not supported")
 }
 
+// HealthCheck reports an error if the application isn't healthy.
+func (*common) HealthCheck(_ context.Context) error {
+	return nil
+}
+
 // Initialize runs first time initialization.
 func (a *common) Initialize(_ context.Context) error {
 	a.appState.Initialized = true
@@ -111,6 +116,23 @@ func (*common) NeedsLateUpdateCheck() bool {
 	return false
 }
 
+// NetworkDidChange is called once a new network configuration has been applied.
+func (*common) NetworkDidChange(_ context.Context) {}
+
+// NetworkWillChange is called before a new network configuration is applied.
+func (*common) NetworkWillChange(_ context.Context) error {
+	return nil
+}
+
+// PreferredBridge returns the name of the bridge to use as the default instance network, if any.
+func (a *common) PreferredBridge() string {
+	if a.state.System.Network.Config == nil {
+		return ""
+	}
+
+	return a.state.System.Network.Config.PreferredBridge
+}
+
 // Restart restarts runs restart action.
 func (*common) Restart(_ context.Context) error {
 	return nil
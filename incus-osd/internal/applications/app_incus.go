@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -190,6 +191,15 @@ func (a *incus) GetServerCertificate() (*tls.Certificate, error) {
 	return cert, nil
 }
 
+// HealthCheck reports an error if the application isn't healthy.
+func (a *incus) HealthCheck(ctx context.Context) error {
+	if !a.IsRunning(ctx) {
+		return errors.New("incus.service isn't running")
+	}
+
+	return nil
+}
+
 // Initialize runs first time initialization.
 func (a *incus) Initialize(ctx context.Context) error {
 	// Get the preseed from the seed partition.
@@ -272,6 +282,49 @@ func (*incus) NeedsLateUpdateCheck() bool {
 	return false
 }
 
+// NetworkDidChange logs the end of the network change window.
+func (a *incus) NetworkDidChange(ctx context.Context) {
+	if !a.IsRunning(ctx) {
+		return
+	}
+
+	slog.InfoContext(ctx, "Network configuration change completed")
+}
+
+// NetworkWillChange logs the start of the network change window, as instance connectivity may be disrupted.
+func (a *incus) NetworkWillChange(ctx context.Context) error {
+	if !a.IsRunning(ctx) {
+		return nil
+	}
+
+	// Failing to list the instances isn't a reason to block the network change.
+	c, err := incusclient.ConnectIncusUnix("", nil)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to connect to Incus ahead of network change", "err", err)
+
+		return nil
+	}
+
+	instances, err := c.GetInstances(incusapi.InstanceTypeAny)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to list instances ahead of network change", "err", err)
+
+		return nil
+	}
+
+	running := 0
+
+	for _, instance := range instances {
+		if instance.StatusCode == incusapi.Running {
+			running++
+		}
+	}
+
+	slog.InfoContext(ctx, "Network configuration is changing, instance connectivity may be disrupted", "running", running)
+
+	return nil
+}
+
 // Restart restarts the main systemd unit.
 func (*incus) Restart(ctx context.Context) error {
 	return systemd.RestartUnit(ctx, "incus.service")
@@ -533,13 +586,23 @@ func (a *incus) applyDefaults(ctx context.Context, c incusclient.InstanceServer)
 			return err
 		}
 
-		// Add to the default profile.
+		// Add to the default profile, connecting directly to the preferred bridge if one is configured.
 		profileDefault.Devices["eth0"] = map[string]string{
 			"type":    "nic",
 			"network": "incusbr0",
 			"name":    "eth0",
 		}
 
+		bridge := a.PreferredBridge()
+		if bridge != "" {
+			profileDefault.Devices["eth0"] = map[string]string{
+				"type":    "nic",
+				"nictype": "bridged",
+				"parent":  bridge,
+				"name":    "eth0",
+			}
+		}
+
 		// Add physical network entries for all interfaces listed with the instances role.
 		for _, iface := range a.state.System.Network.State.GetInterfaceNamesByRole(api.SystemNetworkInterfaceRoleInstances) {
 			// We only want to handle bridges.
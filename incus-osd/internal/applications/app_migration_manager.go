@@ -147,6 +147,15 @@ func (*migrationManager) GetServerCertificate() (*tls.Certificate, error) {
 	return &cert, nil
 }
 
+// HealthCheck reports an error if the application isn't healthy.
+func (mm *migrationManager) HealthCheck(ctx context.Context) error {
+	if !mm.IsRunning(ctx) {
+		return errors.New("migration-manager.service isn't running")
+	}
+
+	return nil
+}
+
 // Initialize runs first time initialization.
 func (mm *migrationManager) Initialize(ctx context.Context) error {
 	// Get the preseed from the seed partition.
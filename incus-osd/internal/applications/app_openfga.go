@@ -118,6 +118,15 @@ func (*openfga) GetDependencies() []string {
 	return nil
 }
 
+// HealthCheck reports an error if the application isn't healthy.
+func (o *openfga) HealthCheck(ctx context.Context) error {
+	if !o.IsRunning(ctx) {
+		return errors.New("openfga.service isn't running")
+	}
+
+	return nil
+}
+
 // Initialize runs first time initialization.
 func (o *openfga) Initialize(ctx context.Context) error {
 	// Ensure the default configuration directory exists.
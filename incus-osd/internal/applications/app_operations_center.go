@@ -153,6 +153,15 @@ func (*operationsCenter) GetServerCertificate() (*tls.Certificate, error) {
 	return &cert, nil
 }
 
+// HealthCheck reports an error if the application isn't healthy.
+func (oc *operationsCenter) HealthCheck(ctx context.Context) error {
+	if !oc.IsRunning(ctx) {
+		return errors.New("operations-center.service isn't running")
+	}
+
+	return nil
+}
+
 // Initialize runs first time initialization.
 func (oc *operationsCenter) Initialize(ctx context.Context) error {
 	// Get the preseed from the seed partition.
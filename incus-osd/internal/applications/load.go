@@ -3,6 +3,7 @@ package applications
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
@@ -97,6 +98,37 @@ func GetInstalled(ctx context.Context, s *state.State) ([]Application, error) {
 	return apps, nil
 }
 
+// GetNetworkChangeHooks returns the hooks notifying every installed application of a network change.
+func GetNetworkChangeHooks(s *state.State) systemd.NetworkChangeHooks {
+	return systemd.NetworkChangeHooks{
+		WillChange: func(ctx context.Context) error {
+			apps, err := GetInstalled(ctx, s)
+			if err != nil {
+				return err
+			}
+
+			for _, app := range apps {
+				err := app.NetworkWillChange(ctx)
+				if err != nil {
+					return fmt.Errorf("application '%s': %w", app.Name(), err)
+				}
+			}
+
+			return nil
+		},
+		DidChange: func(ctx context.Context) {
+			apps, err := GetInstalled(ctx, s)
+			if err != nil {
+				return
+			}
+
+			for _, app := range apps {
+				app.NetworkDidChange(ctx)
+			}
+		},
+	}
+}
+
 // GetInstallApplications returns a list of applications that should be installed on the system.
 // If no applications are currently installed, attempt to get an application list from the seed.
 // If no primary application is defined, the "incus" application will be automatically selected.
@@ -23,6 +23,7 @@ type Application interface { //nolint:interfacebloat
 	GetClientCertificate() (*tls.Certificate, error)
 	GetDependencies() []string
 	GetServerCertificate() (*tls.Certificate, error)
+	HealthCheck(ctx context.Context) error
 	Initialize(ctx context.Context) error
 	IsInitialized() bool
 	IsInstalled() bool
@@ -30,6 +31,9 @@ type Application interface { //nolint:interfacebloat
 	IsRunning(ctx context.Context) bool
 	Name() string
 	NeedsLateUpdateCheck() bool
+	NetworkDidChange(ctx context.Context)
+	NetworkWillChange(ctx context.Context) error
+	PreferredBridge() string
 	Restart(ctx context.Context) error
 	RestoreBackup(archive io.Reader) error
 	SetFriendlyVersion(ctx context.Context) error
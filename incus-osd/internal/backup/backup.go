@@ -337,6 +337,12 @@ func processNewState(ctx context.Context, s *state.State, skipOptions []string)
 				s.System.Network.Config.Bonds[i].Hwaddr = s.System.Network.Config.Bonds[i].Name
 			}
 		}
+
+		for i := range s.System.Network.Config.Teams {
+			if s.System.Network.Config.Teams[i].Hwaddr != "" {
+				s.System.Network.Config.Teams[i].Hwaddr = s.System.Network.Config.Teams[i].Name
+			}
+		}
 	}
 
 	if !slices.Contains(skipOptions, "encryption-recovery-keys") {
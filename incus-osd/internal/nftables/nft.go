@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -32,6 +33,12 @@ func SetupChains(ctx context.Context) error {
 		return err
 	}
 
+	// Ensure we have a TCP MSS clamping chain.
+	_, err = subprocess.RunCommandContext(ctx, "nft", "add", "chain", "inet", "incus-osd", "mss-clamp", "{ type filter hook forward priority mangle ; policy accept ; }")
+	if err != nil {
+		return err
+	}
+
 	// Ensure we have a bridge table.
 	_, err = subprocess.RunCommandContext(ctx, "nft", "add", "table", "bridge", "incus-osd")
 	if err != nil {
@@ -44,10 +51,17 @@ func SetupChains(ctx context.Context) error {
 		return err
 	}
 
+	// Ensure we have a MAC locking chain, run before the bridge learns the source address.
+	_, err = subprocess.RunCommandContext(ctx, "nft", "add", "chain", "bridge", "incus-osd", "mac-locks", "{ type filter hook prerouting priority 0 ; policy accept ; }")
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// ApplyHwaddrFilters ensures that all interfaces with the StrictHwaddr flag set get a suitable MAC filter in place.
+// ApplyHwaddrFilters ensures that all interfaces with the StrictHwaddr flag set get a suitable MAC filter in place,
+// and that the bridges of interfaces and bonds with MACLock set only accept their allowed source addresses.
 func ApplyHwaddrFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
 	// Make sure we have the expected chains.
 	err := SetupChains(ctx)
@@ -55,27 +69,116 @@ func ApplyHwaddrFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig
 		return err
 	}
 
-	// Empty the chain.
-	_, err = subprocess.RunCommandContext(ctx, "nft", "flush", "chain", "bridge", "incus-osd", "mac-filters")
-	if err != nil {
-		return err
+	// Empty the chains.
+	for _, chain := range []string{"mac-filters", "mac-locks"} {
+		_, err = subprocess.RunCommandContext(ctx, "nft", "flush", "chain", "bridge", "incus-osd", chain)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Apply the filters.
+	for underlyingDevice, hwaddrs := range getStrictHwaddrFilters(networkCfg) {
+		_, err = subprocess.RunCommandContext(ctx, "nft", "add", "rule", "bridge", "incus-osd", "mac-filters", "oifname", underlyingDevice, "ether", "saddr", "!=", "{"+strings.Join(hwaddrs, ",")+"}", "drop")
+		if err != nil {
+			return err
+		}
+	}
+
+	for bridge, lock := range getMACLocks(networkCfg) {
+		_, err = subprocess.RunCommandContext(ctx, "nft", "add", "rule", "bridge", "incus-osd", "mac-locks", "ibrname", bridge, "iifname", "!=", "{"+strings.Join(lock.exemptPorts, ",")+"}", "ether", "saddr", "!=", "{"+strings.Join(lock.hwaddrs, ",")+"}", "drop")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getAllowedHwaddrs returns the sorted, lower case list of the device's own MAC, that of any VLAN on top
+// of it and any extra MAC.
+func getAllowedHwaddrs(networkCfg *api.SystemNetworkConfig, name string, hwaddr string, extraHwaddrs []string) []string {
+	hwaddrs := []string{strings.ToLower(hwaddr)}
+
+	for _, vlan := range networkCfg.VLANs {
+		if vlan.Parent == name && vlan.Hwaddr != "" {
+			hwaddrs = append(hwaddrs, strings.ToLower(vlan.Hwaddr))
+		}
+	}
+
+	for _, extraHwaddr := range extraHwaddrs {
+		hwaddrs = append(hwaddrs, strings.ToLower(extraHwaddr))
+	}
+
+	slices.Sort(hwaddrs)
+
+	return slices.Compact(hwaddrs)
+}
+
+// getStrictHwaddrFilters returns the allowed source MAC addresses leaving the physical device of each interface
+// with StrictHwaddr set, being the interface's own MAC and that of any VLAN on top of it.
+func getStrictHwaddrFilters(networkCfg *api.SystemNetworkConfig) map[string][]string {
+	ret := map[string][]string{}
+
 	for _, iface := range networkCfg.Interfaces {
 		if !iface.StrictHwaddr {
 			continue
 		}
 
-		underlyingDevice := "_p" + strings.ToLower(strings.ReplaceAll(iface.Hwaddr, ":", ""))
+		ret["_p"+strings.ToLower(strings.ReplaceAll(iface.Hwaddr, ":", ""))] = getAllowedHwaddrs(networkCfg, iface.Name, iface.Hwaddr, nil)
+	}
 
-		_, err = subprocess.RunCommandContext(ctx, "nft", "add", "rule", "bridge", "incus-osd", "mac-filters", "oifname", underlyingDevice, "ether", "saddr", "!=", iface.Hwaddr, "drop")
-		if err != nil {
-			return err
+	return ret
+}
+
+// macLock describes the source MAC restriction of a locked bridge.
+type macLock struct {
+	// The uplink and host veth ports, whose traffic isn't restricted.
+	exemptPorts []string
+
+	// The source MAC addresses allowed from any other port.
+	hwaddrs []string
+}
+
+// getMACLocks returns the MAC restriction of each locked bridge. Besides the listed addresses, the device's
+// own MAC and that of any VLAN on top of it are always allowed, so the host's traffic isn't dropped.
+func getMACLocks(networkCfg *api.SystemNetworkConfig) map[string]macLock {
+	ret := map[string]macLock{}
+
+	addLock := func(name string, uplink string, vethPeer string, hwaddr string, extraHwaddrs []string) {
+		ret[name] = macLock{
+			exemptPorts: []string{uplink, vethPeer},
+			hwaddrs:     getAllowedHwaddrs(networkCfg, name, hwaddr, extraHwaddrs),
 		}
 	}
 
-	return nil
+	for _, iface := range networkCfg.Interfaces {
+		if !iface.MACLock {
+			continue
+		}
+
+		vethPeer := iface.VethPeerName
+		if vethPeer == "" {
+			vethPeer = "_i" + strings.ToLower(strings.ReplaceAll(iface.Hwaddr, ":", ""))
+		}
+
+		addLock(iface.Name, "_p"+strings.ToLower(strings.ReplaceAll(iface.Hwaddr, ":", "")), vethPeer, iface.Hwaddr, iface.MACLockHwaddrs)
+	}
+
+	for _, bond := range networkCfg.Bonds {
+		if !bond.MACLock {
+			continue
+		}
+
+		hwaddr := bond.Hwaddr
+		if hwaddr == "" {
+			hwaddr = bond.Members[0]
+		}
+
+		addLock(bond.Name, "_b"+bond.Name, "_i"+strings.ToLower(strings.ReplaceAll(hwaddr, ":", "")), hwaddr, bond.MACLockHwaddrs)
+	}
+
+	return ret
 }
 
 // ApplyForwardFilters blocks routing between IncusOS-managed interfaces.
@@ -104,6 +207,10 @@ func ApplyForwardFilters(ctx context.Context, networkCfg *api.SystemNetworkConfi
 		ifaces = append(ifaces, "_v"+iface.Name)
 	}
 
+	for _, iface := range networkCfg.Teams {
+		ifaces = append(ifaces, "_v"+iface.Name)
+	}
+
 	for _, iface := range networkCfg.VLANs {
 		ifaces = append(ifaces, iface.Name)
 	}
@@ -112,10 +219,60 @@ func ApplyForwardFilters(ctx context.Context, networkCfg *api.SystemNetworkConfi
 		ifaces = append(ifaces, iface.Name)
 	}
 
+	for _, iface := range networkCfg.IPVLANs {
+		ifaces = append(ifaces, iface.Name)
+	}
+
+	for _, iface := range networkCfg.Tunnels {
+		if iface.Kind == "gretap" {
+			ifaces = append(ifaces, "_v"+iface.Name)
+		} else {
+			ifaces = append(ifaces, iface.Name)
+		}
+	}
+
 	if len(ifaces) == 0 {
 		return nil
 	}
 
+	// Allow traffic to be routed between the devices with forwarding enabled for the same address family.
+	forwardingIfaces := map[string][]string{}
+
+	addForwarding := func(name string, ipForwarding string) {
+		if ipForwarding == "ipv4" || ipForwarding == "both" {
+			forwardingIfaces["ipv4"] = append(forwardingIfaces["ipv4"], name)
+		}
+
+		if ipForwarding == "ipv6" || ipForwarding == "both" {
+			forwardingIfaces["ipv6"] = append(forwardingIfaces["ipv6"], name)
+		}
+	}
+
+	for _, iface := range networkCfg.Interfaces {
+		addForwarding("_v"+iface.Name, iface.IPForwarding)
+	}
+
+	for _, iface := range networkCfg.Bonds {
+		addForwarding("_v"+iface.Name, iface.IPForwarding)
+	}
+
+	for _, iface := range networkCfg.VLANs {
+		addForwarding(iface.Name, iface.IPForwarding)
+	}
+
+	for _, family := range []string{"ipv4", "ipv6"} {
+		if len(forwardingIfaces[family]) < 2 {
+			continue
+		}
+
+		forwardingSet := "{" + strings.Join(forwardingIfaces[family], ",") + "}"
+
+		_, err = subprocess.RunCommandContext(ctx, "nft", "add", "rule", "inet", "incus-osd", "forward", "meta", "nfproto", family, "iifname", forwardingSet, "oifname", forwardingSet, "accept")
+		if err != nil {
+			return err
+		}
+	}
+
 	// Drop any traffic being routed from one IncusOS-managed interface to another.
 	set := "{" + strings.Join(ifaces, ",") + "}"
 
@@ -127,8 +284,8 @@ func ApplyForwardFilters(ctx context.Context, networkCfg *api.SystemNetworkConfi
 	return nil
 }
 
-// ApplyInputFilters applies the input firewall rules.
-func ApplyInputFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+// ApplyMSSClampFilters clamps the MSS of forwarded TCP connections to the path MTU for devices with TCPMSSClamp set.
+func ApplyMSSClampFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
 	// Make sure we have the expected chains.
 	err := SetupChains(ctx)
 	if err != nil {
@@ -136,11 +293,100 @@ func ApplyInputFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig)
 	}
 
 	// Empty the chain.
-	_, err = subprocess.RunCommandContext(ctx, "nft", "flush", "chain", "inet", "incus-osd", "input")
+	_, err = subprocess.RunCommandContext(ctx, "nft", "flush", "chain", "inet", "incus-osd", "mss-clamp")
 	if err != nil {
 		return err
 	}
 
+	// Get the list of devices requiring clamping.
+	ifaces := []string{}
+
+	for _, iface := range networkCfg.Wireguard {
+		if iface.TCPMSSClamp {
+			ifaces = append(ifaces, iface.Name)
+		}
+	}
+
+	for _, iface := range networkCfg.Tunnels {
+		if !iface.TCPMSSClamp {
+			continue
+		}
+
+		if iface.Kind == "gretap" {
+			ifaces = append(ifaces, "_v"+iface.Name)
+		} else {
+			ifaces = append(ifaces, iface.Name)
+		}
+	}
+
+	if len(ifaces) == 0 {
+		return nil
+	}
+
+	set := "{" + strings.Join(ifaces, ",") + "}"
+
+	// Clamp in both directions, so the SYN/ACK coming back through the device is clamped too.
+	for _, match := range []string{"oifname", "iifname"} {
+		_, err = subprocess.RunCommandContext(ctx, "nft", "add", "rule", "inet", "incus-osd", "mss-clamp", match, set, "tcp", "flags", "syn", "/", "syn,rst", "tcp", "option", "maxseg", "size", "set", "rt", "mtu")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplyInputFilters applies the input firewall rules. The chain is flushed and re-populated in a
+// single nft transaction, so if any rule fails to load the existing rules are left untouched.
+func ApplyInputFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	// Make sure we have the expected chains.
+	err := SetupChains(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ruleset strings.Builder
+
+	_, _ = ruleset.WriteString("flush chain inet incus-osd input\n")
+
+	// Get the devices running VRRP, whose advertisements must always be allowed.
+	vrrpDevices := map[string]bool{}
+
+	for _, iface := range networkCfg.Interfaces {
+		vrrpDevices["_v"+iface.Name] = len(iface.VRRP) > 0
+	}
+
+	for _, iface := range networkCfg.Bonds {
+		vrrpDevices["_v"+iface.Name] = len(iface.VRRP) > 0
+	}
+
+	for _, iface := range networkCfg.VLANs {
+		vrrpDevices[iface.Name] = len(iface.VRRP) > 0
+	}
+
+	// Get the devices taking part in IGMP proxying, whose membership reports must always be allowed.
+	igmpNames := []string{}
+
+	if networkCfg.IGMPProxy != nil {
+		for _, iface := range networkCfg.IGMPProxy.Interfaces {
+			igmpNames = append(igmpNames, iface.Name)
+		}
+	}
+
+	igmpDevices := map[string]bool{}
+
+	for _, iface := range networkCfg.Interfaces {
+		igmpDevices["_v"+iface.Name] = slices.Contains(igmpNames, iface.Name)
+	}
+
+	for _, iface := range networkCfg.Bonds {
+		igmpDevices["_v"+iface.Name] = slices.Contains(igmpNames, iface.Name)
+	}
+
+	for _, iface := range networkCfg.VLANs {
+		igmpDevices[iface.Name] = slices.Contains(igmpNames, iface.Name)
+	}
+
 	// Apply the filters.
 	applyFirewall := func(iface string, firewallRules []api.SystemNetworkFirewallRule) error {
 		// Baseline rules.
@@ -152,6 +398,14 @@ func ApplyInputFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig)
 			{"icmpv6", "type", "{echo-request,nd-neighbor-solicit,nd-neighbor-advert,nd-router-solicit,nd-router-advert,mld-listener-query}", "accept"},
 		}
 
+		if vrrpDevices[iface] {
+			rules = append(rules, []string{"meta", "l4proto", "vrrp", "accept"})
+		}
+
+		if igmpDevices[iface] {
+			rules = append(rules, []string{"meta", "l4proto", "igmp", "accept"})
+		}
+
 		// Add the user rules.
 		for _, firewallRule := range firewallRules {
 			rule := []string{}
@@ -189,13 +443,9 @@ func ApplyInputFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig)
 			rules = append(rules, rule)
 		}
 
-		// Apply the interface rules.
-		args := []string{"add", "rule", "inet", "incus-osd", "input", "iifname", iface}
+		// Add the interface rules to the ruleset.
 		for _, rule := range rules {
-			_, err = subprocess.RunCommandContext(ctx, "nft", append(args, rule...)...)
-			if err != nil {
-				return err
-			}
+			_, _ = fmt.Fprintf(&ruleset, "add rule inet incus-osd input iifname %s %s\n", iface, strings.Join(rule, " "))
 		}
 
 		return nil
@@ -223,6 +473,17 @@ func ApplyInputFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig)
 		}
 	}
 
+	for _, iface := range networkCfg.Teams {
+		if len(iface.FirewallRules) == 0 {
+			continue
+		}
+
+		err := applyFirewall("_v"+iface.Name, iface.FirewallRules)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, iface := range networkCfg.VLANs {
 		if len(iface.FirewallRules) == 0 {
 			continue
@@ -245,5 +506,33 @@ func ApplyInputFilters(ctx context.Context, networkCfg *api.SystemNetworkConfig)
 		}
 	}
 
-	return nil
+	for _, iface := range networkCfg.IPVLANs {
+		if len(iface.FirewallRules) == 0 {
+			continue
+		}
+
+		err := applyFirewall(iface.Name, iface.FirewallRules)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, iface := range networkCfg.Tunnels {
+		if len(iface.FirewallRules) == 0 {
+			continue
+		}
+
+		name := iface.Name
+		if iface.Kind == "gretap" {
+			name = "_v" + iface.Name
+		}
+
+		err := applyFirewall(name, iface.FirewallRules)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Atomically load the new ruleset.
+	return subprocess.RunCommandWithFds(ctx, strings.NewReader(ruleset.String()), nil, "nft", "-f", "-")
 }
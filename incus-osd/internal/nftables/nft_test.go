@@ -0,0 +1,33 @@
+package nftables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+func TestMACLocks(t *testing.T) {
+	t.Parallel()
+
+	networkCfg := api.SystemNetworkConfig{
+		Interfaces: []api.SystemNetworkInterface{
+			{Name: "uplink", Hwaddr: "10:66:6A:B0:5F:01", MACLock: true, MACLockHwaddrs: []string{"10:66:6A:00:02:00"}},
+			{Name: "san", Hwaddr: "10:66:6a:b0:5f:02", VethPeerName: "san-peer", MACLock: true, MACLockHwaddrs: []string{"10:66:6a:00:02:01"}},
+			{Name: "unlocked", Hwaddr: "10:66:6a:b0:5f:03"},
+		},
+		Bonds: []api.SystemNetworkBond{
+			{Name: "bond0", Members: []string{"10:66:6a:b0:5f:04", "10:66:6a:b0:5f:05"}, MACLock: true, MACLockHwaddrs: []string{"10:66:6a:00:02:02"}},
+		},
+		VLANs: []api.SystemNetworkVLAN{
+			{Name: "uplink10", Parent: "uplink", ID: 10, Hwaddr: "10:66:6a:b0:5f:10"},
+		},
+	}
+
+	require.Equal(t, map[string]macLock{
+		"uplink": {exemptPorts: []string{"_p10666ab05f01", "_i10666ab05f01"}, hwaddrs: []string{"10:66:6a:00:02:00", "10:66:6a:b0:5f:01", "10:66:6a:b0:5f:10"}},
+		"san":    {exemptPorts: []string{"_p10666ab05f02", "san-peer"}, hwaddrs: []string{"10:66:6a:00:02:01", "10:66:6a:b0:5f:02"}},
+		"bond0":  {exemptPorts: []string{"_bbond0", "_i10666ab05f04"}, hwaddrs: []string{"10:66:6a:00:02:02", "10:66:6a:b0:5f:04"}},
+	}, getMACLocks(&networkCfg))
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -150,7 +151,7 @@ func (*Server) apiDebugLog(w http.ResponseWriter, r *http.Request) {
 	bootNumber := r.Form.Get("boot")
 	numEntries := r.Form.Get("entries")
 
-	journalCmdArgs := []string{"-o", "json"}
+	journalCmdArgs := []string{}
 
 	if unitName != "" {
 		journalCmdArgs = append(journalCmdArgs, "-u", unitName)
@@ -166,13 +167,23 @@ func (*Server) apiDebugLog(w http.ResponseWriter, r *http.Request) {
 		journalCmdArgs = append(journalCmdArgs, "-n", numEntries)
 	}
 
-	jsonOutput, err := subprocess.RunCommandContext(r.Context(), "journalctl", journalCmdArgs...)
+	jsonObj, err := getJournalEntries(r.Context(), journalCmdArgs...)
 	if err != nil {
 		_ = response.InternalError(err).Render(w)
 
 		return
 	}
 
+	_ = response.SyncResponse(true, jsonObj).Render(w)
+}
+
+// getJournalEntries runs journalctl with the provided arguments and returns the parsed JSON entries.
+func getJournalEntries(ctx context.Context, args ...string) ([]map[string]any, error) {
+	jsonOutput, err := subprocess.RunCommandContext(ctx, "journalctl", append([]string{"-o", "json"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonObj := []map[string]any{}
 
 	for line := range strings.SplitSeq(jsonOutput, "\n") {
@@ -184,15 +195,13 @@ func (*Server) apiDebugLog(w http.ResponseWriter, r *http.Request) {
 
 		err = json.Unmarshal([]byte(line), &obj)
 		if err != nil {
-			_ = response.InternalError(err).Render(w)
-
-			return
+			return nil, err
 		}
 
 		jsonObj = append(jsonObj, obj)
 	}
 
-	_ = response.SyncResponse(true, jsonObj).Render(w)
+	return jsonObj, nil
 }
 
 // swagger:operation GET /1.0/debug/processes debug debug_get_processes
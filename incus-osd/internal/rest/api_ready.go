@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lxc/incus-os/incus-osd/internal/applications"
+	"github.com/lxc/incus-os/incus-osd/internal/rest/response"
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// swagger:operation GET /1.0/ready server ready_get
+//
+//	Get the system readiness
+//
+//	Returns successfully once the network configuration has been applied, all devices required
+//	for the network to be online are online and all installed applications are healthy.
+//	Otherwise a 503 error is returned along with the list of failed checks.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: System is ready
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          description: Response type
+//	          example: sync
+//	          type: string
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of failed checks
+//	          items:
+//	            type: string
+//	          example: []
+//	  "503":
+//	    description: System isn't ready
+//	    schema:
+//	      type: object
+//	      description: Error response
+//	      properties:
+//	        type:
+//	          description: Response type
+//	          example: error
+//	          type: string
+//	        error:
+//	          type: string
+//	          description: Error message
+//	          example: system isn't ready
+//	        error_code:
+//	          type: integer
+//	          description: Error code
+//	          example: 503
+//	        metadata:
+//	          type: array
+//	          description: List of failed checks
+//	          items:
+//	            type: string
+//	          example: ["network device uplink isn't online", "application incus: incus.service isn't running"]
+func (s *Server) apiReady(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	failures := []string{}
+
+	// Check the network configuration.
+	networkState := s.state.System.Network.State
+
+	switch {
+	case s.state.System.Network.Config == nil:
+		failures = append(failures, "no network configuration defined")
+	case networkState.ConfigurationInProcess:
+		failures = append(failures, "network configuration in progress")
+	case networkState.ConfigurationError != "":
+		failures = append(failures, "network configuration failed: "+networkState.ConfigurationError)
+	case !networkState.ConfigurationApplied:
+		failures = append(failures, "network configuration not applied")
+	default:
+		for _, dev := range systemd.GetOfflineDevices(r.Context(), s.state.System.Network.Config) {
+			failures = append(failures, "network device "+dev+" isn't online")
+		}
+	}
+
+	// Check the applications.
+	apps, err := applications.GetInstalled(r.Context(), s.state)
+	if err != nil {
+		failures = append(failures, "failed to get installed applications: "+err.Error())
+	}
+
+	for _, app := range apps {
+		err := app.HealthCheck(r.Context())
+		if err != nil {
+			failures = append(failures, "application "+app.Name()+": "+err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		_ = response.UnavailableWithMetadata(errors.New("system isn't ready"), failures).Render(w)
+
+		return
+	}
+
+	_ = response.SyncResponse(true, failures).Render(w)
+}
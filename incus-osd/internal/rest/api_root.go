@@ -173,6 +173,10 @@ func (s *Server) apiRoot10(w http.ResponseWriter, r *http.Request) {
 		environment["os_version_alternate"] = ukiVersions.OtherVersion
 	}
 
+	if s.state.OS.LastShutdownReason != "" {
+		environment["last_shutdown_reason"] = s.state.OS.LastShutdownReason
+	}
+
 	resp := map[string]any{
 		"environment": environment,
 	}
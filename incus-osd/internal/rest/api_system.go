@@ -1,9 +1,12 @@
 package rest
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
 
+	"github.com/lxc/incus-os/incus-osd/api"
 	"github.com/lxc/incus-os/incus-osd/internal/rest/response"
 )
 
@@ -66,14 +69,25 @@ func (*Server) apiSystem(w http.ResponseWriter, r *http.Request) {
 //
 //	Power off the system
 //
-//	Powers off the system.
+//	Powers off the system. An optional reason can be provided, which is logged and
+//	recorded as the last shutdown reason.
 //
 //	---
 //	produces:
 //	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: action
+//	    description: Power action data
+//	    required: false
+//	    schema:
+//	      type: object
+//	      example: {"reason":"Scheduled maintenance"}
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
 func (s *Server) apiSystemPoweroff(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -83,6 +97,13 @@ func (s *Server) apiSystemPoweroff(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err := s.recordShutdownReason(r)
+	if err != nil {
+		_ = response.BadRequest(err).Render(w)
+
+		return
+	}
+
 	s.state.TriggerShutdown <- true
 
 	_ = response.EmptySyncResponse.Render(w)
@@ -92,14 +113,25 @@ func (s *Server) apiSystemPoweroff(w http.ResponseWriter, r *http.Request) {
 //
 //	Reboot the system
 //
-//	Reboots the system.
+//	Reboots the system. An optional reason can be provided, which is logged and
+//	recorded as the last shutdown reason.
 //
 //	---
 //	produces:
 //	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: action
+//	    description: Power action data
+//	    required: false
+//	    schema:
+//	      type: object
+//	      example: {"reason":"Scheduled maintenance"}
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
 func (s *Server) apiSystemReboot(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -109,6 +141,13 @@ func (s *Server) apiSystemReboot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err := s.recordShutdownReason(r)
+	if err != nil {
+		_ = response.BadRequest(err).Render(w)
+
+		return
+	}
+
 	s.state.TriggerReboot <- true
 
 	_ = response.EmptySyncResponse.Render(w)
@@ -139,3 +178,24 @@ func (s *Server) apiSystemSuspend(w http.ResponseWriter, r *http.Request) {
 
 	_ = response.EmptySyncResponse.Render(w)
 }
+
+// recordShutdownReason stores the optional reason provided with a reboot or power off request.
+func (s *Server) recordShutdownReason(r *http.Request) error {
+	actionData := &api.SystemPowerAction{}
+
+	counter := &countWrapper{ReadCloser: r.Body}
+
+	err := json.NewDecoder(counter).Decode(actionData)
+	if err != nil && counter.n > 0 {
+		return err
+	}
+
+	if len(actionData.Reason) > 256 {
+		return errors.New("reason can't be longer than 256 characters")
+	}
+
+	s.state.OS.LastShutdownReason = actionData.Reason
+	_ = s.state.Save()
+
+	return nil
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/lxc/incus-os/incus-osd/api"
+	"github.com/lxc/incus-os/incus-osd/internal/applications"
 	"github.com/lxc/incus-os/incus-osd/internal/nftables"
 	"github.com/lxc/incus-os/incus-osd/internal/providers"
 	"github.com/lxc/incus-os/incus-osd/internal/rest/response"
@@ -104,8 +109,11 @@ func (s *Server) apiSystemNetwork(w http.ResponseWriter, r *http.Request) {
 			s.state.System.Network.Config.Time.Timezone = "UTC"
 		}
 
-		// Return the current network state.
-		_ = response.SyncResponse(true, s.state.System.Network).Render(w)
+		// Return the current network state, without any secrets.
+		network := s.state.System.Network
+		network.Config = systemd.RedactNetworkSecrets(network.Config)
+
+		_ = response.SyncResponse(true, network).Render(w)
 	case http.MethodPut:
 		// Replace the existing network configuration.
 		newConfig := &api.SystemNetwork{}
@@ -133,111 +141,138 @@ func (s *Server) apiSystemNetwork(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		var confirmationTimeout time.Duration
+		// Keep any secret that was redacted when the configuration was retrieved.
+		systemd.RestoreNetworkSecrets(newConfig.Config, s.state.System.Network.Config)
 
-		// If a confirmation timeout is provided, make sure it is valid.
-		if newConfig.Config.ConfirmationTimeout != "" {
-			confirmationTimeout, err = time.ParseDuration(newConfig.Config.ConfirmationTimeout)
-			if err != nil {
-				_ = response.BadRequest(errors.New("invalid confirmation timeout provided: " + err.Error())).Render(w)
+		confirmationTimeout, err := ParseConfirmationTimeout(newConfig.Config)
+		if err != nil {
+			_ = response.BadRequest(err).Render(w)
 
-				return
-			}
+			return
+		}
 
-			if confirmationTimeout <= 0 {
-				_ = response.BadRequest(errors.New("confirmation timeout must be greater than zero")).Render(w)
+		slog.InfoContext(r.Context(), "Applying new network configuration")
 
-				return
-			}
+		err = ApplyNetworkConfiguration(r.Context(), s.state, newConfig.Config, confirmationTimeout)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to update network configuration: "+err.Error())
+			_ = networkErrorResponse(err, response.InternalError).Render(w)
 
-			// Clear the configuration timeout after parsing it, so it's not reported back via an API call.
-			newConfig.Config.ConfirmationTimeout = ""
+			return
 		}
 
-		// If a confirmation timeout is defined, start a background function that will roll back changes
-		// unless the user confirms them before the timeout expires.
-		if confirmationTimeout > 0 {
-			s.state.NetworkConfigurationPending = true
-
-			// Save a copy of the existing network configuration in the state, which can be used
-			// to restore things if the system is rebooted before the confirmation timeout can
-			// roll things back automatically.
-			s.state.PriorNetworkConfig = s.state.System.Network.Config
-
-			// #nosec G118
-			go func(ctx context.Context) { //nolint:contextcheck
-				select {
-				case err := <-s.state.NetworkConfigurationChannel:
-					// If we get a non-nil error from the channel, something's
-					// gone wrong attempting to apply the new network configuration.
-					// Automatically roll it back without waiting for the timeout
-					// to expire. If the error is nil, there's nothing special
-					// that needs to be done.
-					if err != nil {
-						slog.WarnContext(ctx, "Invalid network configuration detected, rolling back to prior known-good state")
+		_ = response.EmptySyncResponse.Render(w)
+	default:
+		// If none of the supported methods, return NotImplemented.
+		_ = response.NotImplemented(nil).Render(w)
+	}
+}
 
-						err = applyNetworkConfiguration(ctx, s.state, s.state.PriorNetworkConfig, 30*time.Second)
-						if err != nil {
-							slog.ErrorContext(ctx, "Failed to roll back network configuration: "+err.Error())
-						}
-					}
-				case <-time.After(confirmationTimeout):
-					// At this point, the user-provided timeout has elapsed and the changes were not confirmed,
-					// so we need to roll the changes back.
-					slog.WarnContext(ctx, "Timeout expired, rolling back network configuration to prior known-good state")
+// ParseConfirmationTimeout parses and clears the confirmation timeout of a new network configuration,
+// so it's not reported back via an API call. A zero duration is returned if none is set.
+func ParseConfirmationTimeout(networkCfg *api.SystemNetworkConfig) (time.Duration, error) {
+	if networkCfg.ConfirmationTimeout == "" {
+		return 0, nil
+	}
+
+	confirmationTimeout, err := time.ParseDuration(networkCfg.ConfirmationTimeout)
+	if err != nil {
+		return 0, errors.New("invalid confirmation timeout provided: " + err.Error())
+	}
+
+	if confirmationTimeout <= 0 {
+		return 0, errors.New("confirmation timeout must be greater than zero")
+	}
+
+	networkCfg.ConfirmationTimeout = ""
+
+	return confirmationTimeout, nil
+}
 
-					err = applyNetworkConfiguration(ctx, s.state, s.state.PriorNetworkConfig, 30*time.Second)
+// ApplyNetworkConfiguration applies a new network configuration. If a confirmation timeout is provided,
+// the prior configuration is restored unless the new one is confirmed before the timeout expires.
+func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, confirmationTimeout time.Duration) error {
+	// Don't allow applying a new network configuration if a prior network configuration
+	// is still waiting for confirmation.
+	if s.NetworkConfigurationPending {
+		return errors.New("a pending network configuration must first be confirmed before a new configuration can be applied")
+	}
+
+	// If a confirmation timeout is defined, start a background function that will roll back changes
+	// unless the user confirms them before the timeout expires.
+	if confirmationTimeout > 0 {
+		s.NetworkConfigurationPending = true
+
+		// Save a copy of the existing network configuration in the state, which can be used
+		// to restore things if the system is rebooted before the confirmation timeout can
+		// roll things back automatically.
+		s.PriorNetworkConfig = s.System.Network.Config
+
+		// #nosec G118
+		go func(ctx context.Context) { //nolint:contextcheck
+			select {
+			case err := <-s.NetworkConfigurationChannel:
+				// If we get a non-nil error from the channel, something's
+				// gone wrong attempting to apply the new network configuration.
+				// Automatically roll it back without waiting for the timeout
+				// to expire. If the error is nil, there's nothing special
+				// that needs to be done.
+				if err != nil {
+					slog.WarnContext(ctx, "Invalid network configuration detected, rolling back to prior known-good state")
+
+					err = applyNetworkConfiguration(ctx, s, s.PriorNetworkConfig, 30*time.Second, false)
 					if err != nil {
 						slog.ErrorContext(ctx, "Failed to roll back network configuration: "+err.Error())
 					}
 				}
+			case <-time.After(confirmationTimeout):
+				// At this point, the user-provided timeout has elapsed and the changes were not confirmed,
+				// so we need to roll the changes back.
+				slog.WarnContext(ctx, "Timeout expired, rolling back network configuration to prior known-good state")
+
+				err := applyNetworkConfiguration(ctx, s, s.PriorNetworkConfig, 30*time.Second, false)
+				if err != nil {
+					slog.ErrorContext(ctx, "Failed to roll back network configuration: "+err.Error())
+				}
+			}
 
-				// Reset the network configuration pending state.
-				s.state.NetworkConfigurationPending = false
-
-				// Clear the backup of the old network configuration.
-				s.state.PriorNetworkConfig = nil
-
-				_ = s.state.Save()
-			}(context.Background())
-		}
-
-		// By default we allow 30 seconds for the network configuration to apply. But if a user-provided
-		// confirmation timeout is defined and less than 30 seconds, cap the application timeout to that value.
-		applyTimeout := 30 * time.Second
-		if confirmationTimeout != 0 && confirmationTimeout < applyTimeout {
-			applyTimeout = confirmationTimeout
-		}
+			// Reset the network configuration pending state.
+			s.NetworkConfigurationPending = false
 
-		slog.InfoContext(r.Context(), "Applying new network configuration")
+			// Clear the backup of the old network configuration.
+			s.PriorNetworkConfig = nil
 
-		err = applyNetworkConfiguration(r.Context(), s.state, newConfig.Config, applyTimeout)
-		if err != nil {
-			if s.state.NetworkConfigurationPending {
-				// Trigger an immediate rollback of the bad configuration.
-				s.state.NetworkConfigurationChannel <- err
-			}
+			_ = s.Save()
+		}(context.Background())
+	}
 
-			slog.ErrorContext(r.Context(), "Failed to update network configuration: "+err.Error())
-			_ = response.InternalError(err).Render(w)
+	// By default we allow 30 seconds for the network configuration to apply. But if a user-provided
+	// confirmation timeout is defined and less than 30 seconds, cap the application timeout to that value.
+	applyTimeout := 30 * time.Second
+	if confirmationTimeout != 0 && confirmationTimeout < applyTimeout {
+		applyTimeout = confirmationTimeout
+	}
 
-			return
+	err := applyNetworkConfiguration(ctx, s, networkCfg, applyTimeout, false)
+	if err != nil {
+		if s.NetworkConfigurationPending {
+			// Trigger an immediate rollback of the bad configuration.
+			s.NetworkConfigurationChannel <- err
 		}
 
-		_ = response.EmptySyncResponse.Render(w)
-	default:
-		// If none of the supported methods, return NotImplemented.
-		_ = response.NotImplemented(nil).Render(w)
+		return err
 	}
+
+	return nil
 }
 
-func applyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, timeout time.Duration) error {
+func applyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, timeout time.Duration, force bool) error {
 	err := nftables.ApplyHwaddrFilters(ctx, networkCfg)
 	if err != nil {
 		return err
 	}
 
-	err = systemd.ApplyNetworkConfiguration(ctx, s, networkCfg, timeout, false, providers.Notify, false)
+	err = systemd.ApplyNetworkConfiguration(ctx, s, networkCfg, timeout, false, force, providers.Notify, false, applications.GetNetworkChangeHooks(s))
 	if err != nil {
 		return err
 	}
@@ -245,6 +280,17 @@ func applyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 	return s.Save()
 }
 
+// networkErrorResponse returns a bad request listing all the offending fields for validation errors,
+// otherwise the response returned by fallback.
+func networkErrorResponse(err error, fallback func(error) response.Response) response.Response {
+	validationErrs := systemd.ValidationErrors(err)
+	if len(validationErrs) == 0 {
+		return fallback(err)
+	}
+
+	return response.BadRequestWithMetadata(err, validationErrs)
+}
+
 // swagger:operation POST /1.0/system/network/:confirm system system_post_network_confirm
 //
 //	Confirm a new network configuration
@@ -282,6 +328,567 @@ func (s *Server) apiSystemNetworkConfirm(w http.ResponseWriter, r *http.Request)
 	_ = response.EmptySyncResponse.Render(w)
 }
 
+// swagger:operation POST /1.0/system/network/:fetch system system_post_network_fetch
+//
+//	Fetch and apply a remote network configuration
+//
+//	Retrieves a network configuration from the configured remote source, retrying transient failures, then validates and applies it.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *Server) apiSystemNetworkFetch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	if s.state.System.Network.Config == nil || s.state.System.Network.Config.RemoteSource == nil {
+		_ = response.BadRequest(errors.New("no remote network configuration source is configured")).Render(w)
+
+		return
+	}
+
+	source := s.state.System.Network.Config.RemoteSource
+
+	networkCfg, err := systemd.FetchNetworkConfiguration(r.Context(), *source, 5)
+	if err != nil {
+		_ = response.BadRequest(err).Render(w)
+
+		return
+	}
+
+	if seed.NetworkConfigHasEmptyDevices(*networkCfg) {
+		_ = response.BadRequest(errors.New("network configuration has no devices defined")).Render(w)
+
+		return
+	}
+
+	// Keep fetching from the same source unless the new configuration changes it.
+	if networkCfg.RemoteSource == nil {
+		networkCfg.RemoteSource = source
+	}
+
+	confirmationTimeout, err := ParseConfirmationTimeout(networkCfg)
+	if err != nil {
+		_ = response.BadRequest(err).Render(w)
+
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Applying remote network configuration", "url", source.URL)
+
+	err = ApplyNetworkConfiguration(r.Context(), s.state, networkCfg, confirmationTimeout)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to apply remote network configuration: "+err.Error())
+		_ = networkErrorResponse(err, response.InternalError).Render(w)
+
+		return
+	}
+
+	_ = response.EmptySyncResponse.Render(w)
+}
+
+// swagger:operation POST /1.0/system/network/:reapply system system_post_network_reapply
+//
+//	Re-apply the network configuration
+//
+//	Re-applies the current network configuration without changing it, restoring any runtime state that may have drifted from it.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *Server) apiSystemNetworkReapply(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	if s.state.System.Network.Config == nil {
+		_ = response.BadRequest(errors.New("no network configuration has been applied yet")).Render(w)
+
+		return
+	}
+
+	// Don't re-apply while a new network configuration is still waiting for confirmation.
+	if s.state.NetworkConfigurationPending {
+		_ = response.BadRequest(errors.New("a pending network configuration must first be confirmed before it can be re-applied")).Render(w)
+
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Re-applying current network configuration")
+
+	// Force a restart of systemd-networkd, as the generated files are unchanged.
+	err := applyNetworkConfiguration(r.Context(), s.state, s.state.System.Network.Config, 30*time.Second, true)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to re-apply network configuration: "+err.Error())
+		_ = response.InternalError(err).Render(w)
+
+		return
+	}
+
+	_ = response.EmptySyncResponse.Render(w)
+}
+
+// swagger:operation GET /1.0/system/network/config-archive system system_get_network_config_archive
+//
+//	Get the generated network configuration
+//
+//	Returns a `gzip` compressed tar archive of the generated network configuration files, including a manifest listing the SHA256 checksum of each file.
+//
+//	---
+//	produces:
+//	  - application/json
+//	  - application/gzip
+//	responses:
+//	  "200":
+//	    description: gzip'ed tar archive
+//	    schema:
+//	      type: file
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (*Server) apiSystemNetworkConfigArchive(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	archive, err := systemd.GetNetworkConfigArchive()
+	if err != nil {
+		_ = response.InternalError(err).Render(w)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+
+	_, err = w.Write(archive)
+	if err != nil {
+		_ = response.InternalError(err).Render(w)
+
+		return
+	}
+}
+
+// swagger:operation GET /1.0/system/network/events system system_get_network_events
+//
+//	Stream network events
+//
+//	Streams link (added, removed, operational state) and address (added, removed) changes of the network
+//	devices as server-sent events, until the client disconnects.
+//
+//	---
+//	produces:
+//	  - text/event-stream
+//	responses:
+//	  "200":
+//	    description: Stream of network events, each sent as a JSON encoded SystemNetworkEvent
+//	    schema:
+//	      $ref: "#/definitions/SystemNetworkEvent"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (*Server) apiSystemNetworkEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+
+	err := rc.Flush()
+	if err != nil {
+		return
+	}
+
+	// The watcher returns as soon as the client disconnects and the request context is cancelled.
+	err = systemd.WatchNetworkEvents(r.Context(), time.Second, func(event api.SystemNetworkEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		if err != nil {
+			return err
+		}
+
+		return rc.Flush()
+	})
+	if err != nil && r.Context().Err() == nil {
+		slog.WarnContext(r.Context(), "Network event stream stopped", "error", err)
+	}
+}
+
+// swagger:operation GET /1.0/system/network/log system system_get_network_log
+//
+//	Get network service journal entries
+//
+//	Returns recent systemd journal entries of the network services, optionally filtering by unit and number of returned entries.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: unit
+//	    description: Limit journal entries to the specified unit (systemd-networkd, systemd-resolved or systemd-timesyncd)
+//	    required: false
+//	    type: string
+//	  - in: query
+//	    name: entries
+//	    description: Limit journal entries to the specified number of entries (defaults to 100)
+//	    required: false
+//	    type: integer
+//	responses:
+//	  "200":
+//	    description: systemd journal entries
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          description: Response type
+//	          example: sync
+//	          type: string
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of systemd journal entries
+//	          items:
+//	            type: object
+//	          example: [{"MESSAGE":"_venp5s0: Gained carrier","PRIORITY":"6","SYSLOG_IDENTIFIER":"systemd-networkd","_SYSTEMD_UNIT":"systemd-networkd.service","__REALTIME_TIMESTAMP":"1762272421322883"}]
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (*Server) apiSystemNetworkLog(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	err := r.ParseForm() // #nosec G120
+	if err != nil {
+		_ = response.BadRequest(err).Render(w)
+
+		return
+	}
+
+	units := []string{"systemd-networkd", "systemd-resolved", "systemd-timesyncd"}
+
+	unitName := r.Form.Get("unit")
+	if unitName != "" {
+		if !slices.Contains(units, unitName) {
+			_ = response.BadRequest(fmt.Errorf("unsupported unit %q", unitName)).Render(w)
+
+			return
+		}
+
+		units = []string{unitName}
+	}
+
+	numEntries := 100
+
+	if r.Form.Get("entries") != "" {
+		numEntries, err = strconv.Atoi(r.Form.Get("entries"))
+		if err != nil || numEntries <= 0 || numEntries > 10000 {
+			_ = response.BadRequest(errors.New("entries must be between 1 and 10000")).Render(w)
+
+			return
+		}
+	}
+
+	journalCmdArgs := []string{"-b", "0", "-n", strconv.Itoa(numEntries)}
+
+	for _, unit := range units {
+		journalCmdArgs = append(journalCmdArgs, "-u", unit)
+	}
+
+	jsonObj, err := getJournalEntries(r.Context(), journalCmdArgs...)
+	if err != nil {
+		_ = response.InternalError(err).Render(w)
+
+		return
+	}
+
+	_ = response.SyncResponse(true, jsonObj).Render(w)
+}
+
+// swagger:operation GET /1.0/system/network/metrics system system_get_network_metrics
+//
+//	Get the network metrics
+//
+//	Returns the carrier, online state, address count and traffic counters of each network device,
+//	including observed devices, in the Prometheus text format.
+//
+//	---
+//	produces:
+//	  - text/plain
+//	responses:
+//	  "200":
+//	    description: Network metrics
+//	    schema:
+//	      type: string
+//	      example: incusos_network_interface_carrier{name="bmc0",type="observed"} 1
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *Server) apiSystemNetworkMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	err := systemd.UpdateNetworkState(r.Context(), &s.state.System.Network)
+	if err != nil {
+		_ = response.InternalError(err).Render(w)
+
+		return
+	}
+
+	_ = response.SyncResponsePlain(true, false, systemd.GetNetworkMetrics(s.state.System.Network.State)).Render(w)
+}
+
+// swagger:operation GET /1.0/system/network/physical-interfaces system system_get_network_physical_interfaces
+//
+//	Get the physical network interfaces
+//
+//	Returns the physical Ethernet interfaces present on the system, regardless of the current network configuration.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: List of physical network interfaces
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          description: Response type
+//	          example: sync
+//	          type: string
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: json
+//	          description: List of physical network interfaces
+//	          example: [{"name":"enp5s0","hwaddr":"10:66:6a:1a:20:0f","pci_address":"0000:05:00.0","path":"pci-0000:05:00.0","driver":"virtio_net","speed":"1000","carrier":true}]
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *Server) apiSystemNetworkPhysicalInterfaces(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	ifaces, err := systemd.GetPhysicalInterfaces(r.Context())
+	if err != nil {
+		_ = response.InternalError(err).Render(w)
+
+		return
+	}
+
+	// Label the interface reserved for management, if any.
+	if s.state.System.Network.Config != nil {
+		for idx, iface := range ifaces {
+			ifaces[idx].Management = slices.ContainsFunc(s.state.System.Network.Config.Interfaces, func(i api.SystemNetworkInterface) bool {
+				return i.Management && strings.EqualFold(i.Hwaddr, iface.Hwaddr)
+			})
+		}
+	}
+
+	_ = response.SyncResponse(true, ifaces).Render(w)
+}
+
+// swagger:operation GET /1.0/system/network/reconciliation system system_get_network_reconciliation
+//
+//	Get the network reconciliation status
+//
+//	Compares the applied network configuration against the live state of each configured device,
+//	reporting any missing or extra addresses, missing routes and MTU mismatches.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Per-device reconciliation status
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          description: Response type
+//	          example: sync
+//	          type: string
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: Per-device reconciliation status
+//	          items:
+//	            $ref: "#/definitions/SystemNetworkReconciliation"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *Server) apiSystemNetworkReconciliation(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	if s.state.System.Network.Config == nil {
+		_ = response.SyncResponse(true, []api.SystemNetworkReconciliation{}).Render(w)
+
+		return
+	}
+
+	status, err := systemd.GetNetworkReconciliation(r.Context(), s.state.System.Network.Config)
+	if err != nil {
+		_ = response.InternalError(err).Render(w)
+
+		return
+	}
+
+	_ = response.SyncResponse(true, status).Render(w)
+}
+
+// swagger:operation POST /1.0/system/network/:validate system system_post_network_validate
+//
+//	Validate a network configuration
+//
+//	Checks whether the provided network configuration would be accepted by this system, without applying it.
+//
+//	Validation failures of a specific device field also list the details as SystemNetworkValidationError entries in the error metadata.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: configuration
+//	    description: Network configuration
+//	    required: true
+//	    schema:
+//	      type: object
+//	      properties:
+//	        config:
+//	          type: object
+//	          description: The network configuration
+//	          example: {"interfaces":[{"name":"enp5s0","addresses":["dhcp4"],"required_for_online":"ipv4","hwaddr":"10:66:6a:1a:20:0f","lldp":true}]}
+//	  - in: query
+//	    name: namespace
+//	    description: If true, also bring the configuration up in a throwaway network namespace, with physical NICs replaced by dummy devices
+//	    required: false
+//	    type: boolean
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+func (*Server) apiSystemNetworkValidate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		_ = response.NotImplemented(nil).Render(w)
+
+		return
+	}
+
+	newConfig := &api.SystemNetwork{}
+
+	// Populate the network configuration from request's body.
+	err := json.NewDecoder(r.Body).Decode(newConfig)
+	if err != nil {
+		_ = response.BadRequest(err).Render(w)
+
+		return
+	}
+
+	if newConfig.Config == nil || seed.NetworkConfigHasEmptyDevices(*newConfig.Config) {
+		_ = response.BadRequest(errors.New("network configuration has no devices defined")).Render(w)
+
+		return
+	}
+
+	if r.FormValue("namespace") == "true" {
+		err = systemd.CheckNetworkConfigurationInNamespace(r.Context(), newConfig.Config, 10*time.Second)
+	} else {
+		err = systemd.CheckNetworkConfiguration(r.Context(), newConfig.Config)
+	}
+
+	if err != nil {
+		_ = networkErrorResponse(err, response.BadRequest).Render(w)
+
+		return
+	}
+
+	_ = response.EmptySyncResponse.Render(w)
+}
+
 // swagger:operation POST /1.0/system/network/:flush-dns system system_post_network_flush_dns
 //
 //	Flush the DNS cache
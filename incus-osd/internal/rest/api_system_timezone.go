@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+	"github.com/lxc/incus-os/incus-osd/internal/rest/response"
+	"github.com/lxc/incus-os/incus-osd/internal/seed"
+	"github.com/lxc/incus-os/incus-osd/internal/systemd"
+)
+
+// swagger:operation GET /1.0/system/timezone system system_get_timezone
+//
+//	Get the system timezone
+//
+//	Returns the current system timezone.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: System timezone
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          description: Response type
+//	          example: sync
+//	          type: string
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: json
+//	          description: System timezone
+//	          example: {"timezone":"America/New_York"}
+
+// swagger:operation PUT /1.0/system/timezone system system_put_timezone
+//
+//	Update the system timezone
+//
+//	Validates the timezone against the list of known zones, applies it and persists it in the network time configuration.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: configuration
+//	    description: Timezone configuration
+//	    required: true
+//	    schema:
+//	      type: object
+//	      properties:
+//	        timezone:
+//	          type: string
+//	          description: The timezone
+//	          example: America/New_York
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *Server) apiSystemTimezone(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		timezone, err := systemd.GetTimezone(r.Context())
+		if err != nil {
+			_ = response.InternalError(err).Render(w)
+
+			return
+		}
+
+		_ = response.SyncResponse(true, api.SystemTimezone{Timezone: timezone}).Render(w)
+	case http.MethodPut:
+		timezoneData := &api.SystemTimezone{}
+
+		err := json.NewDecoder(r.Body).Decode(timezoneData)
+		if err != nil {
+			_ = response.BadRequest(err).Render(w)
+
+			return
+		}
+
+		valid, err := systemd.IsValidTimezone(r.Context(), timezoneData.Timezone)
+		if err != nil {
+			_ = response.InternalError(err).Render(w)
+
+			return
+		}
+
+		if !valid {
+			_ = response.BadRequest(fmt.Errorf("unknown timezone '%s'", timezoneData.Timezone)).Render(w)
+
+			return
+		}
+
+		// Apply the timezone. timedatectl updates the /etc/localtime symlink, which lives on the writable /etc.
+		timeCfg := &api.SystemNetworkTime{Timezone: timezoneData.Timezone}
+
+		err = systemd.SetTimezone(r.Context(), timeCfg)
+		if err != nil {
+			_ = response.InternalError(err).Render(w)
+
+			return
+		}
+
+		// Persist the timezone so it's re-applied on boot. Without a network configuration in the state,
+		// start from the seed one, which would otherwise be re-read on boot without the new timezone.
+		networkCfg := s.state.System.Network.Config
+		if networkCfg == nil {
+			networkCfg, err = seed.GetNetwork(r.Context())
+			if err != nil {
+				_ = response.InternalError(err).Render(w)
+
+				return
+			}
+
+			s.state.System.Network.Config = networkCfg
+		}
+
+		if networkCfg.Time == nil {
+			networkCfg.Time = timeCfg
+		} else {
+			networkCfg.Time.Timezone = timezoneData.Timezone
+		}
+
+		err = s.state.Save()
+		if err != nil {
+			_ = response.InternalError(err).Render(w)
+
+			return
+		}
+
+		_ = response.EmptySyncResponse.Render(w)
+	default:
+		// If none of the supported methods, return NotImplemented.
+		_ = response.NotImplemented(nil).Render(w)
+	}
+}
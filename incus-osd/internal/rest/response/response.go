@@ -185,18 +185,24 @@ func (r *syncResponse) Code() int {
 
 // Error response.
 type errorResponse struct {
-	code int    // Code to return in both the HTTP header and Code field of the response body.
-	msg  string // Message to return in the Error field of the response body.
+	code     int    // Code to return in both the HTTP header and Code field of the response body.
+	msg      string // Message to return in the Error field of the response body.
+	metadata any    // Optional details to return in the Metadata field of the response body.
 }
 
 // ErrorResponse returns an error response with the given code and msg.
 func ErrorResponse(code int, msg string) Response {
-	return &errorResponse{code, msg}
+	return &errorResponse{code: code, msg: msg}
 }
 
 // BadRequest returns a bad request response (400) with the given error.
 func BadRequest(err error) Response {
-	return &errorResponse{http.StatusBadRequest, err.Error()}
+	return &errorResponse{code: http.StatusBadRequest, msg: err.Error()}
+}
+
+// BadRequestWithMetadata returns a bad request response (400) with the given error and error details.
+func BadRequestWithMetadata(err error, metadata any) Response {
+	return &errorResponse{code: http.StatusBadRequest, msg: err.Error(), metadata: metadata}
 }
 
 // Conflict returns a conflict response (409) with the given error.
@@ -206,7 +212,7 @@ func Conflict(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusConflict, message}
+	return &errorResponse{code: http.StatusConflict, msg: message}
 }
 
 // Forbidden returns a forbidden response (403) with the given error.
@@ -216,12 +222,12 @@ func Forbidden(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusForbidden, message}
+	return &errorResponse{code: http.StatusForbidden, msg: message}
 }
 
 // InternalError returns an internal error response (500) with the given error.
 func InternalError(err error) Response {
-	return &errorResponse{http.StatusInternalServerError, err.Error()}
+	return &errorResponse{code: http.StatusInternalServerError, msg: err.Error()}
 }
 
 // NotFound returns a not found response (404) with the given error.
@@ -231,7 +237,7 @@ func NotFound(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusNotFound, message}
+	return &errorResponse{code: http.StatusNotFound, msg: message}
 }
 
 // NotImplemented returns a not implemented response (501) with the given error.
@@ -241,13 +247,13 @@ func NotImplemented(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusNotImplemented, message}
+	return &errorResponse{code: http.StatusNotImplemented, msg: message}
 }
 
 // PreconditionFailed returns a precondition failed response (412) with the
 // given error.
 func PreconditionFailed(err error) Response {
-	return &errorResponse{http.StatusPreconditionFailed, err.Error()}
+	return &errorResponse{code: http.StatusPreconditionFailed, msg: err.Error()}
 }
 
 // Unavailable return an unavailable response (503) with the given error.
@@ -257,7 +263,12 @@ func Unavailable(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusServiceUnavailable, message}
+	return &errorResponse{code: http.StatusServiceUnavailable, msg: message}
+}
+
+// UnavailableWithMetadata returns an unavailable response (503) with the given error and error details.
+func UnavailableWithMetadata(err error, metadata any) Response {
+	return &errorResponse{code: http.StatusServiceUnavailable, msg: err.Error(), metadata: metadata}
 }
 
 func (r *errorResponse) String() string {
@@ -271,9 +282,10 @@ func (r *errorResponse) Code() int {
 
 func (r *errorResponse) Render(w http.ResponseWriter) error {
 	resp := api.ResponseRaw{
-		Type:  api.ErrorResponse,
-		Error: r.msg,
-		Code:  r.code, // Set the error code in the Code field of the response body.
+		Type:     api.ErrorResponse,
+		Error:    r.msg,
+		Code:     r.code, // Set the error code in the Code field of the response body.
+		Metadata: r.metadata,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -337,7 +349,7 @@ func Unauthorized(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusUnauthorized, message}
+	return &errorResponse{code: http.StatusUnauthorized, msg: message}
 }
 
 type pipeResponse struct {
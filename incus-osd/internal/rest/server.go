@@ -58,6 +58,7 @@ func (s *Server) Serve() error {
 	router.HandleFunc("/1.0/debug/secureboot", s.apiDebugSecureBoot)
 	router.HandleFunc("/1.0/debug/secureboot/event-log", s.apiDebugSecureBootEventLog)
 	router.HandleFunc("/1.0/debug/secureboot/:update", s.apiDebugSecureBootUpdate)
+	router.HandleFunc("/1.0/ready", s.apiReady)
 	router.HandleFunc("/1.0/services", s.apiServices)
 	router.HandleFunc("/1.0/services/{name}", s.apiServicesEndpoint)
 	router.HandleFunc("/1.0/services/{name}/:reset", s.apiServicesEndpointReset)
@@ -73,7 +74,16 @@ func (s *Server) Serve() error {
 	router.HandleFunc("/1.0/system/logging", s.apiSystemLogging)
 	router.HandleFunc("/1.0/system/network", s.apiSystemNetwork)
 	router.HandleFunc("/1.0/system/network/:confirm", s.apiSystemNetworkConfirm)
+	router.HandleFunc("/1.0/system/network/:fetch", s.apiSystemNetworkFetch)
 	router.HandleFunc("/1.0/system/network/:flush-dns", s.apiSystemNetworkFlushDNS)
+	router.HandleFunc("/1.0/system/network/:reapply", s.apiSystemNetworkReapply)
+	router.HandleFunc("/1.0/system/network/:validate", s.apiSystemNetworkValidate)
+	router.HandleFunc("/1.0/system/network/config-archive", s.apiSystemNetworkConfigArchive)
+	router.HandleFunc("/1.0/system/network/events", s.apiSystemNetworkEvents)
+	router.HandleFunc("/1.0/system/network/log", s.apiSystemNetworkLog)
+	router.HandleFunc("/1.0/system/network/metrics", s.apiSystemNetworkMetrics)
+	router.HandleFunc("/1.0/system/network/physical-interfaces", s.apiSystemNetworkPhysicalInterfaces)
+	router.HandleFunc("/1.0/system/network/reconciliation", s.apiSystemNetworkReconciliation)
 	router.HandleFunc("/1.0/system/provider", s.apiSystemProvider)
 	router.HandleFunc("/1.0/system/resources", s.apiSystemResources)
 	router.HandleFunc("/1.0/system/security", s.apiSystemSecurity)
@@ -87,6 +97,7 @@ func (s *Server) Serve() error {
 	router.HandleFunc("/1.0/system/storage/:import-pool", s.apiSystemStorageImportPool)
 	router.HandleFunc("/1.0/system/storage/:wipe-drive", s.apiSystemStorageWipeDrive)
 	router.HandleFunc("/1.0/system/storage/:scrub-pool", s.apiSystemStorageScrubPool)
+	router.HandleFunc("/1.0/system/timezone", s.apiSystemTimezone)
 	router.HandleFunc("/1.0/system/update", s.apiSystemUpdate)
 	router.HandleFunc("/1.0/system/update/:check", s.apiSystemUpdateCheck)
 
@@ -23,6 +23,9 @@ type OS struct {
 	NextRelease    string `json:"next_release"`
 	SuccessfulBoot bool   `json:"successful_boot"`
 	SystemIsReady  bool   `json:"-"`
+
+	// The reason given for the most recent reboot or power off request.
+	LastShutdownReason string `json:"last_shutdown_reason"`
 }
 
 // State represents the on-disk persistent state.
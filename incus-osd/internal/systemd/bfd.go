@@ -0,0 +1,147 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// applyBFDConfiguration generates the FRR configuration for all routes monitored by BFD and
+// (re)starts FRR. If no route requires BFD, FRR is stopped.
+func applyBFDConfiguration(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	cfgs := generateFRRFileContents(networkCfg)
+	if len(cfgs) == 0 {
+		if !IsActive(ctx, "frr.service") {
+			return nil
+		}
+
+		return StopUnit(ctx, "frr.service")
+	}
+
+	err := os.MkdirAll(FRRConfigPath, 0o755)
+	if err != nil {
+		return err
+	}
+
+	// Write the configuration, only restarting FRR if something changed.
+	changed := false
+
+	for _, cfg := range cfgs {
+		path := filepath.Join(FRRConfigPath, cfg.Name)
+		if fileContentsMatch(path, cfg.Contents) {
+			continue
+		}
+
+		// #nosec G306
+		err := os.WriteFile(path, []byte(cfg.Contents), 0o644)
+		if err != nil {
+			return err
+		}
+
+		changed = true
+	}
+
+	if !changed && IsActive(ctx, "frr.service") {
+		return nil
+	}
+
+	return RestartUnit(ctx, "frr.service")
+}
+
+// generateFRRFileContents generates the FRR daemons and configuration files needed to run a BFD
+// session for each monitored route, with the route being installed by staticd while the session is up.
+func generateFRRFileContents(networkCfg *api.SystemNetworkConfig) []NetworkdConfigFile {
+	var profiles strings.Builder
+
+	var routes strings.Builder
+
+	profileCount := 0
+
+	addRoutes := func(iface string, deviceRoutes []api.SystemNetworkRoute) {
+		for _, route := range deviceRoutes {
+			if route.BFD == nil {
+				continue
+			}
+
+			profile := fmt.Sprintf("route%d", profileCount)
+			profileCount++
+
+			detectMultiplier := route.BFD.DetectMultiplier
+			if detectMultiplier == 0 {
+				detectMultiplier = 3
+			}
+
+			receiveInterval := route.BFD.ReceiveInterval
+			if receiveInterval == 0 {
+				receiveInterval = 300
+			}
+
+			transmitInterval := route.BFD.TransmitInterval
+			if transmitInterval == 0 {
+				transmitInterval = 300
+			}
+
+			_, _ = fmt.Fprintf(&profiles, " profile %s\n  detect-multiplier %d\n  receive-interval %d\n  transmit-interval %d\n exit\n !\n", profile, detectMultiplier, receiveInterval, transmitInterval)
+
+			family := "ip"
+			if net.ParseIP(route.Via).To4() == nil {
+				family = "ipv6"
+			}
+
+			_, _ = fmt.Fprintf(&routes, "%s route %s %s %s bfd profile %s\n", family, route.To, route.Via, iface, profile)
+		}
+	}
+
+	for _, i := range networkCfg.Interfaces {
+		addRoutes(i.Name, i.Routes)
+	}
+
+	for _, b := range networkCfg.Bonds {
+		addRoutes(b.Name, b.Routes)
+	}
+
+	for _, t := range networkCfg.Teams {
+		addRoutes(t.Name, t.Routes)
+	}
+
+	for _, v := range networkCfg.VLANs {
+		addRoutes(v.Name, v.Routes)
+	}
+
+	for _, wg := range networkCfg.Wireguard {
+		addRoutes(wg.Name, wg.Routes)
+	}
+
+	for _, t := range networkCfg.Tunnels {
+		name := t.Name
+		if t.Kind == "gretap" {
+			name = "_v" + t.Name
+		}
+
+		addRoutes(name, t.Routes)
+	}
+
+	for _, v := range networkCfg.IPVLANs {
+		addRoutes(v.Name, v.Routes)
+	}
+
+	if profileCount == 0 {
+		return nil
+	}
+
+	return []NetworkdConfigFile{
+		{
+			Name:     "daemons",
+			Contents: "zebra=yes\nbfdd=yes\nstaticd=yes\nvtysh_enable=yes\nzebra_options=\"-A 127.0.0.1 -s 90000000\"\nbfdd_options=\"-A 127.0.0.1\"\nstaticd_options=\"-A 127.0.0.1\"\n",
+		},
+		{
+			Name:     "frr.conf",
+			Contents: "frr defaults traditional\nlog syslog informational\n!\nbfd\n" + profiles.String() + "exit\n!\n" + routes.String() + "!\n",
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// applyIGMPProxyConfiguration generates the igmpproxy configuration and (re)starts the daemon,
+// stopping it and removing its configuration when no IGMP proxy is configured.
+func applyIGMPProxyConfiguration(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	if networkCfg.IGMPProxy == nil || len(networkCfg.IGMPProxy.Interfaces) == 0 {
+		err := StopUnit(ctx, "igmpproxy.service")
+		if err != nil {
+			return err
+		}
+
+		err = os.Remove(IGMPProxyConfigFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		return nil
+	}
+
+	// Write the configuration, only restarting igmpproxy if something changed.
+	contents := generateIGMPProxyFileContents(networkCfg)
+	changed := !fileContentsMatch(IGMPProxyConfigFile, contents)
+
+	if changed {
+		// #nosec G306
+		err := os.WriteFile(IGMPProxyConfigFile, []byte(contents), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !changed && IsActive(ctx, "igmpproxy.service") {
+		return nil
+	}
+
+	return RestartUnit(ctx, "igmpproxy.service")
+}
+
+// getIGMPProxyDeviceNames returns a map of the interface, bond and VLAN names to their actual device names.
+func getIGMPProxyDeviceNames(networkCfg *api.SystemNetworkConfig) map[string]string {
+	ret := map[string]string{}
+	hostDevices := getHostDevices(*networkCfg)
+
+	for _, i := range networkCfg.Interfaces {
+		ret[i.Name] = hostDevices[i.Name]
+	}
+
+	for _, b := range networkCfg.Bonds {
+		ret[b.Name] = hostDevices[b.Name]
+	}
+
+	for _, v := range networkCfg.VLANs {
+		ret[v.Name] = hostDevices[v.Name]
+	}
+
+	return ret
+}
+
+// generateIGMPProxyFileContents generates the igmpproxy configuration. Every other interface, bond and
+// VLAN is explicitly disabled so that multicast is never forwarded to devices that weren't listed.
+func generateIGMPProxyFileContents(networkCfg *api.SystemNetworkConfig) string {
+	var ret strings.Builder
+
+	_, _ = ret.WriteString("quickleave\n")
+
+	devices := getIGMPProxyDeviceNames(networkCfg)
+	configured := []string{}
+
+	for _, iface := range networkCfg.IGMPProxy.Interfaces {
+		configured = append(configured, iface.Name)
+
+		_, _ = fmt.Fprintf(&ret, "\nphyint %s %s ratelimit 0 threshold 1\n", devices[iface.Name], iface.Role)
+
+		for _, altnet := range iface.AltNets {
+			_, _ = fmt.Fprintf(&ret, "\taltnet %s\n", altnet)
+		}
+	}
+
+	disabled := []string{}
+
+	for name, dev := range devices {
+		if !slices.Contains(configured, name) {
+			disabled = append(disabled, dev)
+		}
+	}
+
+	slices.Sort(disabled)
+
+	for _, dev := range disabled {
+		_, _ = fmt.Fprintf(&ret, "\nphyint %s disabled\n", dev)
+	}
+
+	return ret.String()
+}
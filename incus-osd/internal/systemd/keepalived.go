@@ -0,0 +1,178 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// vrrpDevice holds the VRRP virtual routers of a device, along with its static addresses.
+type vrrpDevice struct {
+	dev       string
+	addresses []string
+	vrrp      []api.SystemNetworkVRRP
+}
+
+// applyVRRPConfiguration generates a keepalived configuration for each device with VRRP virtual
+// routers and (re)starts the corresponding daemon. Daemons for devices that no longer exist are stopped.
+func applyVRRPConfiguration(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	err := os.MkdirAll(KeepalivedConfigPath, 0o755)
+	if err != nil {
+		return err
+	}
+
+	expectedFiles := map[string]bool{}
+
+	for _, d := range getVRRPDevices(networkCfg) {
+		contents := generateKeepalivedFileContents(d)
+
+		// Write the configuration, only restarting keepalived if something changed.
+		name := d.dev + ".conf"
+		expectedFiles[name] = true
+
+		path := filepath.Join(KeepalivedConfigPath, name)
+		changed := !fileContentsMatch(path, contents)
+
+		if changed {
+			// #nosec G306
+			err := os.WriteFile(path, []byte(contents), 0o600)
+			if err != nil {
+				return err
+			}
+		}
+
+		unit := "keepalived@" + d.dev + ".service"
+		if !changed && IsActive(ctx, unit) {
+			continue
+		}
+
+		err = RestartUnit(ctx, unit)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stop any keepalived that is no longer needed and remove stale files.
+	entries, err := os.ReadDir(KeepalivedConfigPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if expectedFiles[entry.Name()] {
+			continue
+		}
+
+		device, isConfig := strings.CutSuffix(entry.Name(), ".conf")
+		if isConfig {
+			err := StopUnit(ctx, "keepalived@"+device+".service")
+			if err != nil {
+				return err
+			}
+		}
+
+		err := os.Remove(filepath.Join(KeepalivedConfigPath, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getVRRPDevices returns all the devices with at least one VRRP virtual router.
+func getVRRPDevices(networkCfg *api.SystemNetworkConfig) []vrrpDevice {
+	ret := []vrrpDevice{}
+
+	for _, i := range networkCfg.Interfaces {
+		if len(i.VRRP) > 0 {
+			ret = append(ret, vrrpDevice{"_v" + i.Name, i.Addresses, i.VRRP})
+		}
+	}
+
+	for _, b := range networkCfg.Bonds {
+		if len(b.VRRP) > 0 {
+			ret = append(ret, vrrpDevice{"_v" + b.Name, b.Addresses, b.VRRP})
+		}
+	}
+
+	for _, v := range networkCfg.VLANs {
+		if len(v.VRRP) > 0 {
+			ret = append(ret, vrrpDevice{v.Name, v.Addresses, v.VRRP})
+		}
+	}
+
+	return ret
+}
+
+// generateKeepalivedFileContents generates the keepalived configuration for the given device, with
+// one VRRP instance per virtual router. Virtual addresses take the prefix length of their subnet.
+func generateKeepalivedFileContents(d vrrpDevice) string {
+	var ret strings.Builder
+
+	for index, vrrp := range d.vrrp {
+		if index > 0 {
+			_, _ = ret.WriteString("\n")
+		}
+
+		priority := vrrp.Priority
+		if priority == 0 {
+			priority = 100
+		}
+
+		advertInterval := vrrp.AdvertInterval
+		if advertInterval == 0 {
+			advertInterval = 1
+		}
+
+		_, _ = fmt.Fprintf(&ret, "vrrp_instance %s_%d {\n", d.dev, vrrp.VRID)
+		_, _ = fmt.Fprintf(&ret, "\tinterface %s\n", d.dev)
+		_, _ = fmt.Fprintf(&ret, "\tvirtual_router_id %d\n", vrrp.VRID)
+		_, _ = fmt.Fprintf(&ret, "\tpriority %d\n", priority)
+		_, _ = fmt.Fprintf(&ret, "\tadvert_int %d\n", advertInterval)
+
+		if vrrp.AuthPassword != "" {
+			_, _ = fmt.Fprintf(&ret, "\tauthentication {\n\t\tauth_type PASS\n\t\tauth_pass %s\n\t}\n", vrrp.AuthPassword)
+		}
+
+		_, _ = ret.WriteString("\tvirtual_ipaddress {\n")
+
+		for _, addr := range vrrp.VirtualAddresses {
+			_, _ = fmt.Fprintf(&ret, "\t\t%s\n", getVRRPAddressWithPrefix(addr, d.addresses))
+		}
+
+		_, _ = ret.WriteString("\t}\n}\n")
+	}
+
+	return ret.String()
+}
+
+// getVRRPAddressWithPrefix returns the virtual address in CIDR notation, using the prefix length of the
+// first static address whose subnet contains it.
+func getVRRPAddressWithPrefix(addr string, addresses []string) string {
+	ip := net.ParseIP(addr)
+
+	for _, address := range addresses {
+		_, subnet, err := net.ParseCIDR(address)
+		if err != nil {
+			continue
+		}
+
+		if subnet.Contains(ip) {
+			ones, _ := subnet.Mask.Size()
+
+			return fmt.Sprintf("%s/%d", addr, ones)
+		}
+	}
+
+	if ip.To4() != nil {
+		return addr + "/32"
+	}
+
+	return addr + "/128"
+}
@@ -2,11 +2,13 @@ package systemd
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net"
 	"os"
 	"path/filepath"
@@ -27,10 +29,31 @@ import (
 	"github.com/lxc/incus-os/incus-osd/internal/state"
 )
 
+// Numeric filename prefixes of the generated systemd-networkd files. networkd processes each file type
+// in lexical order, so .link files use 00-09, .netdev files 10-19 and .network files 20-29.
+const (
+	LinkFilePriority    = 0
+	NetdevFilePriority  = 10
+	NetworkFilePriority = 20
+)
+
+// Offsets of each device type within the .netdev and .network ranges. Interfaces, bonds and VLANs can
+// shift their own files further using their Priority field.
+const (
+	interfaceFileOffset = iota
+	bondFileOffset
+	vlanFileOffset
+	wireguardFileOffset
+	tunnelFileOffset
+	vrfFileOffset
+	ipvlanFileOffset
+	teamFileOffset
+)
+
 var muNetworkState sync.Mutex
 
-// networkdConfigFile represents a given filename and its contents.
-type networkdConfigFile struct {
+// NetworkdConfigFile represents a given filename and its contents.
+type NetworkdConfigFile struct {
 	Name     string
 	Contents string
 }
@@ -42,8 +65,19 @@ type expPhysDev struct {
 	Hwaddr    string
 }
 
+// NetworkChangeHooks are run around the application of a network configuration, letting applications
+// coordinate with the change.
+type NetworkChangeHooks struct {
+	// Called before any device is torn down or configuration regenerated. An error aborts the change.
+	WillChange func(ctx context.Context) error
+
+	// Called once the network has converged or the change failed, if WillChange succeeded.
+	DidChange func(ctx context.Context)
+}
+
 // ApplyNetworkConfiguration instructs systemd-networkd to apply the supplied network configuration.
-func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, timeout time.Duration, allowPartialConfig bool, refresh func(context.Context, *state.State, ocapi.ServerSelfUpdateCause) error, delayRefreshCheck bool) error {
+// If force is set, systemd-networkd is restarted even if nothing changed, correcting any runtime drift.
+func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, timeout time.Duration, allowPartialConfig bool, force bool, refresh func(context.Context, *state.State, ocapi.ServerSelfUpdateCause) error, delayRefreshCheck bool, hooks NetworkChangeHooks) error {
 	if s.System.Network.State.ConfigurationInProcess {
 		return errors.New("a network configuration is already in progress")
 	}
@@ -54,6 +88,43 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 		s.System.Network.State.ConfigurationInProcess = false
 	}()
 
+	err := applyNetworkConfiguration(ctx, s, networkCfg, timeout, allowPartialConfig, force, refresh, delayRefreshCheck, hooks)
+
+	// Record the outcome so readiness checks can report it.
+	s.System.Network.State.ConfigurationApplied = err == nil
+	s.System.Network.State.ConfigurationError = ""
+
+	if err != nil {
+		s.System.Network.State.ConfigurationError = err.Error()
+	}
+
+	// Push the outcome to the webhook, if any.
+	if networkCfg.Webhook != nil {
+		event := api.SystemNetworkWebhookEvent{
+			Error:     s.System.Network.State.ConfigurationError,
+			State:     s.System.Network.State,
+			Timestamp: time.Now(),
+			Type:      NetworkEventConfigurationApplied,
+		}
+
+		event.State.ConfigurationInProcess = false
+
+		if err != nil {
+			event.Type = NetworkEventConfigurationFailed
+		}
+
+		notifyNetworkWebhook(ctx, *networkCfg.Webhook, event)
+	}
+
+	// Only watch for link state changes while a webhook is configured.
+	network := api.SystemNetwork{Config: networkCfg, State: s.System.Network.State}
+	network.State.ConfigurationInProcess = false
+	restartNetworkWebhookWatcher(ctx, network)
+
+	return err
+}
+
+func applyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *api.SystemNetworkConfig, timeout time.Duration, allowPartialConfig bool, force bool, refresh func(context.Context, *state.State, ocapi.ServerSelfUpdateCause) error, delayRefreshCheck bool, hooks NetworkChangeHooks) error {
 	// If a timezone is specified, apply it before doing any network configuration.
 	err := SetTimezone(ctx, networkCfg.Time)
 	if err != nil {
@@ -80,9 +151,27 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 		return err
 	}
 
+	// Let the applications prepare for the change, aborting if any of them can't.
+	if hooks.WillChange != nil {
+		err = hooks.WillChange(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to prepare applications for the network change: %w", err)
+		}
+
+		if hooks.DidChange != nil {
+			defer hooks.DidChange(ctx)
+		}
+	}
+
+	// Tear down the minimal early boot network, if any, before the physical devices get renamed.
+	err = removeMinimalNetworkConfiguration(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Delete any interfaces, bonds, or vlans that currently exist but don't in
 	// the new configuration, or have a different configuration.
-	err = cleanupStaleDevices(ctx, s.System.Network.Config, networkCfg)
+	devicesRemoved, err := cleanupStaleDevices(ctx, s.System.Network.Config, networkCfg)
 	if err != nil {
 		return err
 	}
@@ -99,6 +188,7 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 	expectedNewPhysicalDevices := getExpectedNewPhysicalDevices(ctx, networkCfg)
 
 	// Update the state before (re)generating networking configuration.
+	previousCfg := s.System.Network.Config
 	s.System.Network.Config = networkCfg
 
 	// Apply the configured hostname, or reset back to default if not set.
@@ -113,7 +203,7 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 		return err
 	}
 
-	err = generateNetworkConfiguration(ctx, networkCfg)
+	networkdChanged, reconfigureDevices, timesyncChanged, err := generateNetworkConfiguration(ctx, networkCfg)
 	if err != nil {
 		return err
 	}
@@ -123,11 +213,41 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 		return err
 	}
 
+	// Apply per-device sysctls, such as disabling IPv6.
+	err = applySysctlConfiguration(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	// Apply the global systemd-resolved options.
+	err = applyResolvedConfiguration(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	// Apply any custom udev rules, prior to udev renaming the new devices.
+	err = applyUdevRules(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
 	err = waitForUdevInterfaceRename(ctx, expectedNewPhysicalDevices, 5*time.Second)
 	if err != nil {
 		return err
 	}
 
+	// Start teamd for each team, now that its members have been renamed.
+	err = applyTeamConfiguration(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	// Start 802.1X authentication on any interface that requires it.
+	err = applyDot1XConfiguration(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
 	// Apply the ingress firewall rules.
 	err = nftables.ApplyInputFilters(ctx, networkCfg)
 	if err != nil {
@@ -140,14 +260,42 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 		return err
 	}
 
-	// Restart networking after new config files have been generated.
-	err = RestartUnit(ctx, "systemd-networkd")
+	// Apply TCP MSS clamping.
+	err = nftables.ApplyMSSClampFilters(ctx, networkCfg)
 	if err != nil {
 		return err
 	}
 
-	// Wait for the network to apply.
-	err = waitForNetworkOnline(ctx, networkCfg, timeout)
+	// Restart networking if the device topology changed, any device needs to be recreated or a restart is forced.
+	// If only .network files were added, changed or removed, reload once and reconfigure the affected devices.
+	restartNetworkd := force || networkdChanged || devicesRemoved || !IsActive(ctx, "systemd-networkd")
+
+	reloadStart := time.Now()
+
+	if !restartNetworkd && len(reconfigureDevices) > 0 {
+		err = reconfigureNetworkDevices(ctx, reconfigureDevices)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to reconfigure network devices, restarting systemd-networkd", "err", err)
+
+			restartNetworkd = true
+		} else {
+			slog.DebugContext(ctx, "Reconfigured network devices", "devices", reconfigureDevices, "duration", time.Since(reloadStart))
+		}
+	}
+
+	if restartNetworkd {
+		err = RestartUnit(ctx, "systemd-networkd")
+		if err != nil {
+			return err
+		}
+
+		slog.DebugContext(ctx, "Restarted systemd-networkd", "duration", time.Since(reloadStart))
+	}
+
+	// Wait for the network to apply, recording any required device that never came online.
+	offlineDevices, err := waitForNetworkOnline(ctx, networkCfg, timeout)
+	setDegradedDevices(&s.System.Network.State, offlineDevices)
+
 	if err != nil {
 		return err
 	}
@@ -164,15 +312,53 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 
 	// (Re)start NTP time synchronization. Since we might be overriding the default fallback NTP servers,
 	// the service is disabled by default and only started once we have performed the network (re)configuration.
-	err = RestartUnit(ctx, "systemd-timesyncd")
+	if timesyncChanged || !IsActive(ctx, "systemd-timesyncd") {
+		err = RestartUnit(ctx, "systemd-timesyncd")
+		if err != nil {
+			return err
+		}
+
+		// Wait up to 30 seconds for NTP synchronization, but don't fail if it doesn't happen.
+		err = waitForSystemdTimesyncd(ctx, 30*time.Second)
+		if err != nil {
+			slog.WarnContext(ctx, "systemd-timesyncd failed to perform NTP synchronization, system time may be incorrect")
+		}
+	}
+
+	// Seed the reachable static neighbors, now that the devices are up.
+	err = applyReachableNeighbors(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	// Police the traffic received by rate limited bridge ports.
+	err = applyIngressRateLimits(ctx, networkCfg)
 	if err != nil {
 		return err
 	}
 
-	// Wait up to 30 seconds for NTP synchronization, but don't fail if it doesn't happen.
-	err = waitForSystemdTimesyncd(ctx, 30*time.Second)
+	// Enable hardware timestamping on the physical devices requesting it, disabling it on the others.
+	err = applyHardwareTimestamping(ctx, previousCfg, networkCfg)
 	if err != nil {
-		slog.WarnContext(ctx, "systemd-timesyncd failed to perform NTP synchronization, system time may be incorrect")
+		return err
+	}
+
+	// (Re)start VRRP on any device with virtual routers.
+	err = applyVRRPConfiguration(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	// (Re)start the IGMP proxy if multicast forwarding is configured.
+	err = applyIGMPProxyConfiguration(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	// (Re)start BFD monitoring of any route next-hops that require it.
+	err = applyBFDConfiguration(ctx, networkCfg)
+	if err != nil {
+		return err
 	}
 
 	// Refresh the state struct.
@@ -204,42 +390,89 @@ func ApplyNetworkConfiguration(ctx context.Context, s *state.State, networkCfg *
 }
 
 // ValidateNetworkConfiguration performs some basic validation checks on the supplied network configuration.
+// Any interface groups are first expanded using the physical NICs present on the system, which are also
+// used to check that observed devices aren't managed under their permanent MAC address.
 func ValidateNetworkConfiguration(networkCfg *api.SystemNetworkConfig, requireValidMAC bool) error {
 	if networkCfg == nil {
 		return errors.New("no network configuration provided")
 	}
 
-	// Check that all interface/bond/vlan names and MACs are unique.
+	if len(networkCfg.InterfaceGroups) == 0 && len(networkCfg.Observed) == 0 {
+		return validateNetworkConfiguration(networkCfg, requireValidMAC, nil)
+	}
+
+	nics, err := getPhysicalNICs(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// Expand any interface groups into concrete interfaces.
+	if len(networkCfg.InterfaceGroups) > 0 {
+		err = expandInterfaceGroups(networkCfg, nics)
+		if err != nil {
+			return err
+		}
+	}
+
+	return validateNetworkConfiguration(networkCfg, requireValidMAC, getPhysicalNICHwaddr(nics))
+}
+
+// LintNetworkConfiguration performs the same checks as ValidateNetworkConfiguration without accessing the
+// system, so candidate configurations can be checked offline, such as in CI. Interface groups are checked
+// but not expanded, and interface names used in place of MACs aren't resolved. The checks requiring a live
+// system, resolving names to MACs and ensuring every referenced NIC exists, are done by CheckNetworkConfiguration.
+func LintNetworkConfiguration(networkCfg *api.SystemNetworkConfig) error {
+	if networkCfg == nil {
+		return errors.New("no network configuration provided")
+	}
+
+	// Without any NICs, expansion only checks the interface group definitions.
+	err := expandInterfaceGroups(networkCfg, nil)
+	if err != nil {
+		return err
+	}
+
+	return validateNetworkConfiguration(networkCfg, false, nil)
+}
+
+// validateNetworkConfiguration performs the validation checks that don't depend on the system. It mustn't
+// run any command or access the filesystem, so it can be used by LintNetworkConfiguration. The optional
+// getHwaddr function looks up the permanent MAC of a live device; when nil, the checks using it are skipped.
+func validateNetworkConfiguration(networkCfg *api.SystemNetworkConfig, requireValidMAC bool, getHwaddr func(name string) string) error {
+	// Check that all interface/bond/vlan names and MACs are unique. MACs are compared
+	// case-insensitively, so that the same device can't be referenced from two places.
 	names := []string{}
 	macs := []string{}
 
 	for _, iface := range networkCfg.Interfaces {
 		if slices.Contains(names, iface.Name) {
-			return errors.New("duplicate interface/bond/vlan/wireguard name: " + iface.Name)
+			return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + iface.Name)
 		}
 
-		if slices.Contains(macs, iface.Hwaddr) {
+		if slices.Contains(macs, strings.ToLower(iface.Hwaddr)) {
 			return errors.New("duplicate MAC address: " + iface.Hwaddr)
 		}
 
 		names = append(names, iface.Name)
-		macs = append(macs, iface.Hwaddr)
+		macs = append(macs, strings.ToLower(iface.Hwaddr))
 	}
 
+	bondMembers := map[string]string{}
+
 	for _, bond := range networkCfg.Bonds {
 		if slices.Contains(names, bond.Name) {
-			return errors.New("duplicate interface/bond/vlan/wireguard name: " + bond.Name)
+			return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + bond.Name)
 		}
 
 		names = append(names, bond.Name)
 
 		// A bond configuration may not explicitly define a MAC, so only check if one is present.
 		if bond.Hwaddr != "" {
-			if slices.Contains(macs, bond.Hwaddr) {
+			if slices.Contains(macs, strings.ToLower(bond.Hwaddr)) {
 				return errors.New("duplicate MAC address: " + bond.Hwaddr)
 			}
 
-			macs = append(macs, bond.Hwaddr)
+			macs = append(macs, strings.ToLower(bond.Hwaddr))
 		}
 
 		// Check that each bond member has a unique MAC, with the exception that the
@@ -247,53 +480,131 @@ func ValidateNetworkConfiguration(networkCfg *api.SystemNetworkConfig, requireVa
 		numMembersWithBondMac := 0
 
 		for _, memberMAC := range bond.Members {
-			if memberMAC == bond.Hwaddr {
+			// Members can't be shared between bonds, as each would try to enslave it.
+			otherBond, ok := bondMembers[strings.ToLower(memberMAC)]
+			if ok && otherBond != bond.Name {
+				return fmt.Errorf("bond member %s is already a member of bond '%s'", memberMAC, otherBond)
+			}
+
+			bondMembers[strings.ToLower(memberMAC)] = bond.Name
+
+			if strings.EqualFold(memberMAC, bond.Hwaddr) {
 				numMembersWithBondMac++
 
 				if numMembersWithBondMac > 1 {
 					return errors.New("duplicate MAC address: " + memberMAC)
 				}
 			} else {
-				if slices.Contains(macs, memberMAC) {
+				if slices.Contains(macs, strings.ToLower(memberMAC)) {
 					return errors.New("duplicate MAC address: " + memberMAC)
 				}
 
-				macs = append(macs, memberMAC)
+				macs = append(macs, strings.ToLower(memberMAC))
 			}
 		}
 	}
 
+	for _, team := range networkCfg.Teams {
+		if slices.Contains(names, team.Name) {
+			return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + team.Name)
+		}
+
+		names = append(names, team.Name)
+
+		// Team members are checked against bond members by validateTeams.
+		if team.Hwaddr != "" && !slices.ContainsFunc(team.Members, func(m string) bool { return strings.EqualFold(m, team.Hwaddr) }) {
+			if slices.Contains(macs, strings.ToLower(team.Hwaddr)) {
+				return errors.New("duplicate MAC address: " + team.Hwaddr)
+			}
+
+			macs = append(macs, strings.ToLower(team.Hwaddr))
+		}
+	}
+
 	for _, vlan := range networkCfg.VLANs {
 		if slices.Contains(names, vlan.Name) {
-			return errors.New("duplicate interface/bond/vlan/wireguard name: " + vlan.Name)
+			return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + vlan.Name)
 		}
 
 		names = append(names, vlan.Name)
+
+		// A VLAN only has its own MAC if one is explicitly defined.
+		if vlan.Hwaddr != "" {
+			if slices.Contains(macs, strings.ToLower(vlan.Hwaddr)) {
+				return errors.New("duplicate MAC address: " + vlan.Hwaddr)
+			}
+
+			macs = append(macs, strings.ToLower(vlan.Hwaddr))
+		}
 	}
 
 	for _, wg := range networkCfg.Wireguard {
 		if slices.Contains(names, wg.Name) {
-			return errors.New("duplicate interface/bond/vlan/wireguard name: " + wg.Name)
+			return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + wg.Name)
 		}
 
 		names = append(names, wg.Name)
 	}
 
+	for _, tunnel := range networkCfg.Tunnels {
+		if slices.Contains(names, tunnel.Name) {
+			return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + tunnel.Name)
+		}
+
+		names = append(names, tunnel.Name)
+	}
+
+	for _, vrf := range networkCfg.VRFs {
+		if slices.Contains(names, vrf.Name) {
+			return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + vrf.Name)
+		}
+
+		names = append(names, vrf.Name)
+	}
+
+	for _, ipvlan := range networkCfg.IPVLANs {
+		if slices.Contains(names, ipvlan.Name) {
+			return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + ipvlan.Name)
+		}
+
+		names = append(names, ipvlan.Name)
+	}
+
+	// Alternative names share the namespace of the device names.
+	for _, iface := range networkCfg.Interfaces {
+		for _, altName := range iface.AlternativeNames {
+			if slices.Contains(names, altName) {
+				return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + altName)
+			}
+
+			names = append(names, altName)
+		}
+
+		if iface.VethPeerName != "" {
+			if slices.Contains(names, iface.VethPeerName) {
+				return errors.New("duplicate interface/bond/team/vlan/wireguard/tunnel/vrf/ipvlan name: " + iface.VethPeerName)
+			}
+
+			names = append(names, iface.VethPeerName)
+		}
+	}
+
 	// Some USB NICs have a default name of "enx<MAC>", which is 15 characters long.
 	// To work around this, strip the leading "enx" before validating network interfaces.
 	mangleUSBNICs(networkCfg)
 
-	err := validateInterfaces(networkCfg.Interfaces, requireValidMAC)
+	// Report all interface, bond and VLAN errors at once, so they can be fixed in a single pass.
+	err := errors.Join(validateInterfaces(networkCfg.Interfaces, networkCfg.VLANs, requireValidMAC), validateBonds(networkCfg.Bonds, networkCfg.VLANs, requireValidMAC), validateVLANs(networkCfg))
 	if err != nil {
 		return err
 	}
 
-	err = validateBonds(networkCfg.Bonds, requireValidMAC)
+	err = validateTeams(networkCfg, requireValidMAC)
 	if err != nil {
 		return err
 	}
 
-	err = validateVLANs(networkCfg)
+	err = validateDefaultPVIDOverlap(networkCfg)
 	if err != nil {
 		return err
 	}
@@ -303,96 +614,319 @@ func ValidateNetworkConfiguration(networkCfg *api.SystemNetworkConfig, requireVa
 		return err
 	}
 
-	return nil
-}
-
-// UpdateNetworkState updates the network state within the SystemNetwork struct.
-func UpdateNetworkState(ctx context.Context, n *api.SystemNetwork) error {
-	var err error
-
-	// Prevent concurrent updates to the state information.
-	muNetworkState.Lock()
-	defer muNetworkState.Unlock()
-
-	if n.Config == nil {
-		return errors.New("no network configuration defined")
+	err = validateTunnels(networkCfg)
+	if err != nil {
+		return err
 	}
 
-	// Clear any existing state.
-	n.State = api.SystemNetworkState{
-		Interfaces: make(map[string]api.SystemNetworkInterfaceState),
+	err = validateVRFs(networkCfg)
+	if err != nil {
+		return err
 	}
 
-	// Keep track of all the roles being applied.
-	rolesFound := []string{}
+	err = validateIPVLANs(networkCfg)
+	if err != nil {
+		return err
+	}
 
-	// State update for interfaces.
-	for _, i := range n.Config.Interfaces {
-		iState, err := getInterfaceState(ctx, "interface", i.Name, i.Hwaddr, "", nil)
-		if err != nil {
-			return err
-		}
+	err = validatePrefixDelegation(networkCfg)
+	if err != nil {
+		return err
+	}
 
-		iState.Roles = i.Roles
-		rolesFound = append(rolesFound, i.Roles...)
-		n.State.Interfaces[i.Name] = iState
+	err = validateDefaultRouteSource(networkCfg)
+	if err != nil {
+		return err
 	}
 
-	// State update for bonds.
-	for _, b := range n.Config.Bonds {
-		members := make(map[string]api.SystemNetworkInterfaceState)
+	err = validatePreferredBridge(networkCfg)
+	if err != nil {
+		return err
+	}
 
-		for _, m := range b.Members {
-			mName := "_p" + strings.ToLower(strings.ReplaceAll(m, ":", ""))
+	err = validateOnlineGroups(networkCfg)
+	if err != nil {
+		return err
+	}
 
-			members[mName], err = getInterfaceState(ctx, "bond_member", mName, m, "", nil)
-			if err != nil {
-				return err
-			}
-		}
+	err = validateIGMPProxy(networkCfg)
+	if err != nil {
+		return err
+	}
 
-		bState, err := getInterfaceState(ctx, "bond", b.Name, b.Hwaddr, "", members)
+	if networkCfg.DNS != nil {
+		err = validateDNSDomains(networkCfg.DNS.SearchDomains, networkCfg.DNS.RoutingDomains)
 		if err != nil {
 			return err
 		}
+	}
 
-		bState.Roles = b.Roles
-		rolesFound = append(rolesFound, b.Roles...)
-		n.State.Interfaces[b.Name] = bState
+	err = validateWebhook(networkCfg.Webhook)
+	if err != nil {
+		return err
 	}
 
-	// State update for vlans.
-	for _, v := range n.Config.VLANs {
-		hwaddr := ""
+	err = validateRemoteSource(networkCfg.RemoteSource)
+	if err != nil {
+		return err
+	}
 
-		parent, ok := n.State.Interfaces[v.Parent]
-		if ok {
-			hwaddr = parent.Hwaddr
-		}
+	err = validateStaticHosts(networkCfg.DNS)
+	if err != nil {
+		return err
+	}
 
-		vState, err := getInterfaceState(ctx, "vlan", v.Name, hwaddr, v.Parent, nil)
-		if err != nil {
-			return err
-		}
+	err = validateResolvedOptions(networkCfg.DNS)
+	if err != nil {
+		return err
+	}
 
-		vState.Roles = v.Roles
-		rolesFound = append(rolesFound, v.Roles...)
-		n.State.Interfaces[v.Name] = vState
+	err = validateTime(networkCfg.Time)
+	if err != nil {
+		return err
 	}
 
-	// State update for wireguard.
-	for _, wg := range n.Config.Wireguard {
-		wgState, err := getWireguardState(ctx, wg.Name)
-		if err != nil {
-			return err
-		}
+	err = validateStaticTimeSources(networkCfg)
+	if err != nil {
+		return err
+	}
 
-		wgState.Roles = wg.Roles
-		rolesFound = append(rolesFound, wg.Roles...)
-		n.State.Interfaces[wg.Name] = wgState
+	err = validateIgnore(networkCfg)
+	if err != nil {
+		return err
 	}
 
-	// Ensure required roles exist.
+	err = validateObserved(networkCfg, names, getHwaddr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckNetworkConfiguration performs the same validation as ApplyNetworkConfiguration, including
+// resolving interface names to MACs and checking that every referenced NIC exists, without making
+// any changes to the system.
+func CheckNetworkConfiguration(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	err := ValidateNetworkConfiguration(networkCfg, false)
+	if err != nil {
+		return err
+	}
+
+	err = resolveMACs(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateNetworkConfiguration(networkCfg, true)
+	if err != nil {
+		return err
+	}
+
+	// Check that all the physical NICs referenced by the configuration are present.
+	nics, err := getPhysicalNICs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return checkPhysicalNICsPresent(networkCfg, nics)
+}
+
+// checkPhysicalNICsPresent checks that every NIC referenced by the configuration is among the provided
+// NICs, matching on their permanent MAC address.
+func checkPhysicalNICsPresent(networkCfg *api.SystemNetworkConfig, nics []physicalNIC) error {
+	nicExists := func(hwaddr string) bool {
+		return slices.ContainsFunc(nics, func(nic physicalNIC) bool {
+			return strings.EqualFold(nic.Hwaddr, hwaddr)
+		})
+	}
+
+	for index, iface := range networkCfg.Interfaces {
+		if !nicExists(iface.Hwaddr) {
+			return fmt.Errorf("interface %d MAC address '%s' doesn't exist on this system", index, iface.Hwaddr)
+		}
+	}
+
+	for index, bond := range networkCfg.Bonds {
+		for memberIndex, member := range bond.Members {
+			if !nicExists(member) {
+				return fmt.Errorf("bond %d member %d MAC address '%s' doesn't exist on this system", index, memberIndex, member)
+			}
+		}
+	}
+
+	for index, team := range networkCfg.Teams {
+		for memberIndex, member := range team.Members {
+			if !nicExists(member) {
+				return fmt.Errorf("team %d member %d MAC address '%s' doesn't exist on this system", index, memberIndex, member)
+			}
+		}
+	}
+
+	return nil
+}
+
+// UpdateNetworkState updates the network state within the SystemNetwork struct.
+func UpdateNetworkState(ctx context.Context, n *api.SystemNetwork) error {
+	var err error
+
+	// Prevent concurrent updates to the state information.
+	muNetworkState.Lock()
+	defer muNetworkState.Unlock()
+
+	if n.Config == nil {
+		return errors.New("no network configuration defined")
+	}
+
+	// Clear any existing interface state.
+	n.State = api.SystemNetworkState{
+		Interfaces:             make(map[string]api.SystemNetworkInterfaceState),
+		ConfigurationApplied:   n.State.ConfigurationApplied,
+		ConfigurationError:     n.State.ConfigurationError,
+		ConfigurationInProcess: n.State.ConfigurationInProcess,
+		Degraded:               n.State.Degraded,
+		DegradedDevices:        n.State.DegradedDevices,
+	}
+
+	// Keep track of all the roles being applied.
+	rolesFound := []string{}
+
+	// State update for interfaces.
+	for _, i := range n.Config.Interfaces {
+		ifaceType := "interface"
+		if i.Management {
+			ifaceType = "management"
+		}
+
+		iState, err := getInterfaceState(ctx, ifaceType, i.Name, i.Hwaddr, "", nil)
+		if err != nil {
+			return err
+		}
+
+		iState.Roles = i.Roles
+		rolesFound = append(rolesFound, i.Roles...)
+		n.State.Interfaces[i.Name] = iState
+	}
+
+	// State update for bonds.
+	for _, b := range n.Config.Bonds {
+		members := make(map[string]api.SystemNetworkInterfaceState)
+
+		for _, m := range b.Members {
+			mName := "_p" + strings.ToLower(strings.ReplaceAll(m, ":", ""))
+
+			members[mName], err = getInterfaceState(ctx, "bond_member", mName, m, "", nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		bState, err := getInterfaceState(ctx, "bond", b.Name, b.Hwaddr, "", members)
+		if err != nil {
+			return err
+		}
+
+		bState.Roles = b.Roles
+		rolesFound = append(rolesFound, b.Roles...)
+		n.State.Interfaces[b.Name] = bState
+	}
+
+	// State update for teams.
+	for _, t := range n.Config.Teams {
+		members := make(map[string]api.SystemNetworkInterfaceState)
+
+		for _, m := range t.Members {
+			mName := "_p" + strings.ToLower(strings.ReplaceAll(m, ":", ""))
+
+			members[mName], err = getInterfaceState(ctx, "team_member", mName, m, "", nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		tState, err := getInterfaceState(ctx, "team", t.Name, t.Hwaddr, "", members)
+		if err != nil {
+			return err
+		}
+
+		tState.Roles = t.Roles
+		rolesFound = append(rolesFound, t.Roles...)
+		n.State.Interfaces[t.Name] = tState
+	}
+
+	// State update for vlans.
+	for _, v := range n.Config.VLANs {
+		hwaddr := ""
+
+		parent, ok := n.State.Interfaces[v.Parent]
+		if ok {
+			hwaddr = parent.Hwaddr
+		}
+
+		vState, err := getInterfaceState(ctx, "vlan", v.Name, hwaddr, v.Parent, nil)
+		if err != nil {
+			return err
+		}
+
+		vState.Roles = v.Roles
+		rolesFound = append(rolesFound, v.Roles...)
+		n.State.Interfaces[v.Name] = vState
+	}
+
+	// State update for IPVLANs.
+	for _, v := range n.Config.IPVLANs {
+		hwaddr := ""
+
+		parent, ok := n.State.Interfaces[v.Parent]
+		if ok {
+			hwaddr = parent.Hwaddr
+		}
+
+		vState, err := getInterfaceState(ctx, "ipvlan", v.Name, hwaddr, v.Parent, nil)
+		if err != nil {
+			return err
+		}
+
+		vState.Roles = v.Roles
+		rolesFound = append(rolesFound, v.Roles...)
+		n.State.Interfaces[v.Name] = vState
+	}
+
+	// State update for wireguard.
+	for _, wg := range n.Config.Wireguard {
+		wgState, err := getWireguardState(ctx, wg.Name)
+		if err != nil {
+			return err
+		}
+
+		wgState.Roles = wg.Roles
+		rolesFound = append(rolesFound, wg.Roles...)
+		n.State.Interfaces[wg.Name] = wgState
+	}
+
+	// State update for tunnels.
+	for _, t := range n.Config.Tunnels {
+		tState, err := getInterfaceState(ctx, "tunnel", t.Name, "", "", nil)
+		if err != nil {
+			return err
+		}
+
+		tState.Roles = t.Roles
+		rolesFound = append(rolesFound, t.Roles...)
+		n.State.Interfaces[t.Name] = tState
+	}
+
+	// State update for VRFs.
+	for _, v := range n.Config.VRFs {
+		vState, err := getInterfaceState(ctx, "vrf", v.Name, "", "", nil)
+		if err != nil {
+			return err
+		}
+
+		n.State.Interfaces[v.Name] = vState
+	}
+
+	// Ensure required roles exist.
 	if !slices.Contains(rolesFound, api.SystemNetworkInterfaceRoleManagement) || !slices.Contains(rolesFound, api.SystemNetworkInterfaceRoleCluster) {
 		for iName, i := range n.State.Interfaces {
 			iState := i
@@ -413,6 +947,32 @@ func UpdateNetworkState(ctx context.Context, n *api.SystemNetwork) error {
 		}
 	}
 
+	// State update for observed devices, configured outside of IncusOS. They never get a default role.
+	for _, name := range n.Config.Observed {
+		_, err := os.Stat("/sys/class/net/" + name)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+
+			n.State.Interfaces[name] = api.SystemNetworkInterfaceState{Type: "observed", State: "missing"}
+
+			continue
+		}
+
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			return err
+		}
+
+		oState, err := getInterfaceState(ctx, "observed", name, iface.HardwareAddr.String(), "", nil)
+		if err != nil {
+			return err
+		}
+
+		n.State.Interfaces[name] = oState
+	}
+
 	// Report any unused additional physical interface.
 	ifaces, err := net.Interfaces()
 	if err != nil {
@@ -476,7 +1036,7 @@ func RestoreWOLMACAddresses(ctx context.Context, s *state.State) {
 			continue
 		}
 
-		iface := "_p" + strings.ToLower(strings.ReplaceAll(i.Hwaddr, ":", ""))
+		iface := getInterfaceDevice(i)
 
 		err := restoreMAC(iface, i.Hwaddr)
 		if err != nil {
@@ -816,11 +1376,13 @@ func getInterfaceState(ctx context.Context, ifaceType string, iface string, hwad
 	var underlyingDevice string
 
 	switch ifaceType {
-	case "interface", "bond_member":
+	case "interface", "bond_member", "team_member":
 		underlyingDevice = "_p" + strings.ToLower(strings.ReplaceAll(hwaddr, ":", ""))
-	case "bond", "physical":
+	case "management":
+		underlyingDevice = "_v" + iface
+	case "bond", "team", "physical", "observed", "tunnel", "vrf":
 		underlyingDevice = iface
-	case "vlan":
+	case "vlan", "ipvlan":
 		if hwaddr == "" {
 			underlyingDevice = parent
 		} else {
@@ -845,7 +1407,7 @@ func getInterfaceState(ctx context.Context, ifaceType string, iface string, hwad
 	// Fetch any LLDP info.
 	lldp := []api.SystemNetworkLLDPState{}
 
-	if ifaceType == "interface" || ifaceType == "bond_member" {
+	if ifaceType == "interface" || ifaceType == "management" || ifaceType == "bond_member" {
 		lldpIface := iface
 		if ifaceType == "interface" {
 			lldpIface = "_p" + strings.ToLower(strings.ReplaceAll(localMAC, ":", ""))
@@ -893,12 +1455,40 @@ func getInterfaceState(ctx context.Context, ifaceType string, iface string, hwad
 func resolveBridge(iface string) string {
 	_, err := os.ReadDir("/sys/class/net/" + iface + "/brif")
 	if err != nil && errors.Is(err, os.ErrNotExist) {
+		// Management interfaces have no bridge, their physical device being renamed directly.
+		_, err := os.Stat("/sys/class/net/" + iface)
+		if err != nil && errors.Is(err, os.ErrNotExist) {
+			_, err := os.Stat("/sys/class/net/_v" + iface)
+			if err == nil {
+				return "_v" + iface
+			}
+		}
+
 		return iface
 	}
 
 	return "_v" + iface
 }
 
+// getInterfaceDevice returns the name of the physical device backing an interface. Management
+// interfaces aren't bridged, so their physical device directly takes the name of the host side.
+func getInterfaceDevice(i api.SystemNetworkInterface) string {
+	if i.Management {
+		return "_v" + i.Name
+	}
+
+	return "_p" + strings.ToLower(strings.ReplaceAll(i.Hwaddr, ":", ""))
+}
+
+// getVethPeerName returns the name of the bridge side of the interface's veth device.
+func getVethPeerName(i api.SystemNetworkInterface) string {
+	if i.VethPeerName != "" {
+		return i.VethPeerName
+	}
+
+	return "_i" + strings.ToLower(strings.ReplaceAll(i.Hwaddr, ":", ""))
+}
+
 // GetIPAddresses returns any non-link-local address for an interface.
 func GetIPAddresses(ctx context.Context, iface string) ([]string, error) {
 	ipAddressRegex := regexp.MustCompile(`inet6? (.+)/\d+ `)
@@ -913,7 +1503,7 @@ func GetIPAddresses(ctx context.Context, iface string) ([]string, error) {
 
 	for _, addr := range matches {
 		// Don't count link-local addresses.
-		if strings.HasPrefix(addr[1], "169.254.") || strings.HasPrefix(addr[1], "fe80:") {
+		if isLinkLocalAddress(addr[1]) {
 			continue
 		}
 
@@ -967,74 +1557,478 @@ func getLLDPInfo(ctx context.Context, iface string) ([]api.SystemNetworkLLDPStat
 }
 
 func generateHosts(_ context.Context, s *state.State) error {
+	var staticHosts map[string]string
+	if s.System.Network.Config != nil && s.System.Network.Config.DNS != nil {
+		staticHosts = s.System.Network.Config.DNS.StaticHosts
+	}
+
 	// Generate the /etc/hosts file.
-	return os.WriteFile("/etc/hosts", fmt.Appendf([]byte{}, `127.0.0.1	localhost
+	return os.WriteFile("/etc/hosts", []byte(generateHostsContents(s.Hostname(), staticHosts)), 0o644)
+}
+
+func generateHostsContents(hostname string, staticHosts map[string]string) string {
+	ret := fmt.Sprintf(`127.0.0.1	localhost
 127.0.1.1	%s
 
 # The following lines are desirable for IPv6 capable hosts
 ::1     localhost ip6-localhost ip6-loopback
 ff02::1 ip6-allnodes
 ff02::2 ip6-allrouters
-`, s.Hostname()), 0o644)
-}
+`, hostname)
 
-// generateNetworkConfiguration clears any existing configuration from /run/systemd/network/ and generates
-// new config files from the supplied NetworkConfig struct.
-func generateNetworkConfiguration(_ context.Context, networkCfg *api.SystemNetworkConfig) error {
-	// Remove any existing configuration.
-	err := os.RemoveAll(SystemdNetworkConfigPath)
-	if err != nil {
-		return err
+	if len(staticHosts) > 0 {
+		ret += "\n# Static hosts\n"
+
+		for _, host := range slices.Sorted(maps.Keys(staticHosts)) {
+			ret += staticHosts[host] + "\t" + host + "\n"
+		}
 	}
 
-	err = os.Mkdir(SystemdNetworkConfigPath, 0o755)
+	return ret
+}
+
+// GenerateNetworkFiles validates the provided configuration, then returns all the .link, .netdev and .network
+// files, followed by the systemd-timesyncd configuration (as "timesyncd.conf") if any, without touching the
+// system. As with LintNetworkConfiguration, interface groups aren't expanded and interface names aren't resolved.
+func GenerateNetworkFiles(networkCfg api.SystemNetworkConfig) ([]NetworkdConfigFile, error) {
+	err := LintNetworkConfiguration(&networkCfg)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Generate .link files.
-	for _, cfg := range generateLinkFileContents(*networkCfg) {
-		err := os.WriteFile(filepath.Join(SystemdNetworkConfigPath, cfg.Name), []byte(cfg.Contents), 0o644)
-		if err != nil {
-			return err
-		}
-	}
+	cfgs := slices.Concat(generateLinkFileContents(networkCfg), generateNetdevFileContents(networkCfg), generateNetworkFileContents(networkCfg))
 
-	// Generate .netdev files.
-	for _, cfg := range generateNetdevFileContents(*networkCfg) {
-		err := os.WriteFile(filepath.Join(SystemdNetworkConfigPath, cfg.Name), []byte(cfg.Contents), 0o644)
-		if err != nil {
-			return err
+	if networkCfg.Time != nil {
+		ntpCfg := generateTimesyncContents(*networkCfg.Time)
+		if ntpCfg != "" {
+			cfgs = append(cfgs, NetworkdConfigFile{Name: filepath.Base(SystemdTimesyncConfigFile), Contents: ntpCfg})
 		}
 	}
 
-	// Generate .network files.
-	for _, cfg := range generateNetworkFileContents(*networkCfg) {
-		err := os.WriteFile(filepath.Join(SystemdNetworkConfigPath, cfg.Name), []byte(cfg.Contents), 0o644)
-		if err != nil {
-			return err
-		}
-	}
+	return cfgs, nil
+}
+
+// generateNetworkConfiguration writes the networkd and timesyncd configuration. If only existing .network files
+// changed, just those are rewritten and the devices they apply to are returned, so they can be reconfigured
+// without restarting networkd. Otherwise, the whole configuration is rewritten and networkdChanged is set.
+func generateNetworkConfiguration(_ context.Context, networkCfg *api.SystemNetworkConfig) (bool, []string, bool, error) {
+	// Generate .link, .netdev and .network files.
+	cfgs := slices.Concat(generateLinkFileContents(*networkCfg), generateNetdevFileContents(*networkCfg), generateNetworkFileContents(*networkCfg))
 
 	// Generate systemd-timesyncd configuration if any timeservers are defined.
 	ntpCfg := ""
 	if networkCfg.Time != nil {
 		ntpCfg = generateTimesyncContents(*networkCfg.Time)
+	}
 
-		if ntpCfg != "" {
-			err := os.WriteFile(SystemdTimesyncConfigFile, []byte(ntpCfg), 0o644)
+	networkdChanged := !networkdConfigMatches(cfgs)
+	timesyncChanged := !fileContentsMatch(SystemdTimesyncConfigFile, ntpCfg)
+
+	var reconfigureDevices []string
+
+	if networkdChanged {
+		changedCfgs, staleFiles, devices, ok := getChangedNetworkFiles(SystemdNetworkConfigPath, cfgs)
+		if ok {
+			// Only write the changed files and remove the stale ones, leaving the rest in place.
+			for _, cfg := range changedCfgs {
+				err := os.WriteFile(filepath.Join(SystemdNetworkConfigPath, cfg.Name), []byte(cfg.Contents), 0o644)
+				if err != nil {
+					return false, nil, false, err
+				}
+			}
+
+			for _, name := range staleFiles {
+				err := os.Remove(filepath.Join(SystemdNetworkConfigPath, name))
+				if err != nil {
+					return false, nil, false, err
+				}
+			}
+
+			networkdChanged = false
+			reconfigureDevices = devices
+		} else {
+			// Remove any existing configuration.
+			err := os.RemoveAll(SystemdNetworkConfigPath)
 			if err != nil {
-				return err
+				return false, nil, false, err
+			}
+
+			err = os.Mkdir(SystemdNetworkConfigPath, 0o755)
+			if err != nil {
+				return false, nil, false, err
 			}
-		}
-	}
 
-	// If there's no NTP configuration, remove the old config file that might exist.
-	if networkCfg.Time == nil || ntpCfg == "" {
-		_ = os.Remove(SystemdTimesyncConfigFile)
+			for _, cfg := range cfgs {
+				err := os.WriteFile(filepath.Join(SystemdNetworkConfigPath, cfg.Name), []byte(cfg.Contents), 0o644)
+				if err != nil {
+					return false, nil, false, err
+				}
+			}
+		}
 	}
 
-	return nil
+	if timesyncChanged {
+		if ntpCfg != "" {
+			err := os.WriteFile(SystemdTimesyncConfigFile, []byte(ntpCfg), 0o644)
+			if err != nil {
+				return false, nil, false, err
+			}
+		} else {
+			// If there's no NTP configuration, remove the old config file that might exist.
+			_ = os.Remove(SystemdTimesyncConfigFile)
+		}
+	}
+
+	return networkdChanged, reconfigureDevices, timesyncChanged, nil
+}
+
+// getChangedNetworkFiles returns the new or modified files compared to those in the provided directory, the stale
+// files to remove and the devices they apply to. This is only possible if only .network files matching a single
+// device by name were added, modified or removed, as a change to any .link or .netdev file alters the device topology.
+func getChangedNetworkFiles(dir string, cfgs []NetworkdConfigFile) ([]NetworkdConfigFile, []string, []string, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, nil, false
+	}
+
+	changedCfgs := []NetworkdConfigFile{}
+	staleFiles := []string{}
+	devices := []string{}
+
+	for _, cfg := range cfgs {
+		if fileContentsMatch(filepath.Join(dir, cfg.Name), cfg.Contents) {
+			continue
+		}
+
+		if filepath.Ext(cfg.Name) != ".network" {
+			return nil, nil, nil, false
+		}
+
+		device, ok := getNetworkFileDevice(cfg.Contents)
+		if !ok {
+			return nil, nil, nil, false
+		}
+
+		changedCfgs = append(changedCfgs, cfg)
+		devices = append(devices, device)
+	}
+
+	for _, entry := range entries {
+		if slices.ContainsFunc(cfgs, func(cfg NetworkdConfigFile) bool { return cfg.Name == entry.Name() }) {
+			continue
+		}
+
+		if filepath.Ext(entry.Name()) != ".network" {
+			return nil, nil, nil, false
+		}
+
+		// #nosec G304
+		contents, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, nil, nil, false
+		}
+
+		device, ok := getNetworkFileDevice(string(contents))
+		if !ok {
+			return nil, nil, nil, false
+		}
+
+		staleFiles = append(staleFiles, entry.Name())
+		devices = append(devices, device)
+	}
+
+	// A device whose file was renamed shows up twice.
+	slices.Sort(devices)
+	devices = slices.Compact(devices)
+
+	return changedCfgs, staleFiles, devices, true
+}
+
+// getNetworkFileDevice returns the device matched by name in the provided .network file contents.
+func getNetworkFileDevice(contents string) (string, bool) {
+	match, ok := strings.CutPrefix(contents, "[Match]\nName=")
+	if !ok {
+		return "", false
+	}
+
+	device, _, _ := strings.Cut(match, "\n")
+	if device == "" || strings.ContainsAny(device, "*?[ ") {
+		return "", false
+	}
+
+	return device, true
+}
+
+// reconfigureNetworkDevices makes networkd reload its configuration and reapply it to the provided devices only.
+func reconfigureNetworkDevices(ctx context.Context, devices []string) error {
+	_, err := subprocess.RunCommandContext(ctx, "networkctl", "reload")
+	if err != nil {
+		return err
+	}
+
+	_, err = subprocess.RunCommandContext(ctx, "networkctl", append([]string{"reconfigure"}, devices...)...)
+
+	return err
+}
+
+// applySysctlConfiguration writes the per-device sysctl drop-in and applies it to existing devices.
+// Devices created later on get the settings applied by udev. Settings no longer present are reset to
+// the kernel defaults.
+func applySysctlConfiguration(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	contents := generateSysctlContents(*networkCfg)
+	if fileContentsMatch(SysctlNetworkConfigFile, contents) {
+		return nil
+	}
+
+	// #nosec G304
+	oldContents, err := os.ReadFile(SysctlNetworkConfigFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	for _, key := range getRemovedSysctls(string(oldContents), contents) {
+		resetSysctl(key)
+	}
+
+	if contents == "" {
+		return os.Remove(SysctlNetworkConfigFile)
+	}
+
+	err = os.MkdirAll(filepath.Dir(SysctlNetworkConfigFile), 0o755)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(SysctlNetworkConfigFile, []byte(contents), 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = subprocess.RunCommandContext(ctx, "/usr/lib/systemd/systemd-sysctl", SysctlNetworkConfigFile)
+
+	return err
+}
+
+// applyUdevRules writes the custom udev rules of each interface, or removes the file when there are none,
+// reloading udev and re-triggering the network devices if anything changed.
+func applyUdevRules(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	contents := generateUdevRulesContents(*networkCfg)
+	if fileContentsMatch(UdevNetworkRulesFile, contents) {
+		return nil
+	}
+
+	if contents == "" {
+		err := os.Remove(UdevNetworkRulesFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else {
+		err := os.MkdirAll(filepath.Dir(UdevNetworkRulesFile), 0o755)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(UdevNetworkRulesFile, []byte(contents), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := subprocess.RunCommandContext(ctx, "udevadm", "control", "--reload")
+	if err != nil {
+		return err
+	}
+
+	_, err = subprocess.RunCommandContext(ctx, "udevadm", "trigger", "--action=change", "--subsystem-match=net")
+	if err != nil {
+		return err
+	}
+
+	_, err = subprocess.RunCommandContext(ctx, "udevadm", "settle")
+
+	return err
+}
+
+// generateUdevRulesContents generates the custom udev rules of each interface. Rules are processed before
+// the .link files, so match on the permanent MAC address, or on the configured one for later change events.
+// Bridges and veth devices sharing the address have no driver, so aren't matched.
+func generateUdevRulesContents(networkCfg api.SystemNetworkConfig) string {
+	var ret strings.Builder
+
+	for _, i := range networkCfg.Interfaces {
+		addresses := []string{strings.ToLower(i.Hwaddr)}
+		if i.MACAddress != "" && !strings.EqualFold(i.MACAddress, i.Hwaddr) {
+			addresses = append(addresses, strings.ToLower(i.MACAddress))
+		}
+
+		for _, rule := range i.UdevRules {
+			_, _ = fmt.Fprintf(&ret, "ACTION==\"add|change\", SUBSYSTEM==\"net\", DRIVERS==\"?*\", ATTR{address}==\"%s\", %s\n", strings.Join(addresses, "|"), rule)
+		}
+	}
+
+	return ret.String()
+}
+
+// applyResolvedConfiguration writes the global systemd-resolved drop-in, or removes it when no option
+// is set, restarting systemd-resolved if anything changed.
+func applyResolvedConfiguration(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	contents := ""
+	if networkCfg.DNS != nil {
+		contents = generateResolvedContents(*networkCfg.DNS)
+	}
+
+	if fileContentsMatch(SystemdResolvedConfigFile, contents) {
+		return nil
+	}
+
+	if contents == "" {
+		err := os.Remove(SystemdResolvedConfigFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else {
+		err := os.MkdirAll(filepath.Dir(SystemdResolvedConfigFile), 0o755)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(SystemdResolvedConfigFile, []byte(contents), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return RestartUnit(ctx, "systemd-resolved")
+}
+
+// generateResolvedContents generates the global systemd-resolved drop-in.
+func generateResolvedContents(dns api.SystemNetworkDNS) string {
+	if dns.LLMNR == "" && dns.MulticastDNS == "" && dns.Cache == "" && !dns.CacheFromLocalhost {
+		return ""
+	}
+
+	var ret strings.Builder
+
+	_, _ = ret.WriteString("[Resolve]\n")
+
+	if dns.LLMNR != "" {
+		_, _ = ret.WriteString("LLMNR=" + dns.LLMNR + "\n")
+	}
+
+	if dns.MulticastDNS != "" {
+		_, _ = ret.WriteString("MulticastDNS=" + dns.MulticastDNS + "\n")
+	}
+
+	if dns.Cache != "" {
+		_, _ = ret.WriteString("Cache=" + dns.Cache + "\n")
+	}
+
+	if dns.CacheFromLocalhost {
+		_, _ = ret.WriteString("CacheFromLocalhost=yes\n")
+	}
+
+	return ret.String()
+}
+
+// generateSysctlContents generates the per-device sysctl drop-in.
+func generateSysctlContents(networkCfg api.SystemNetworkConfig) string {
+	var ret strings.Builder
+
+	// Use the slash separated form so device names containing dots work, and ignore devices that don't exist yet.
+	addDevice := func(dev string, disableIPv6 bool, sysctls map[string]string) {
+		if disableIPv6 {
+			_, _ = fmt.Fprintf(&ret, "-net/ipv6/conf/%s/disable_ipv6 = 1\n", dev)
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(sysctls)) {
+			family, name, _ := strings.Cut(key, ".")
+			_, _ = fmt.Fprintf(&ret, "-net/%s/conf/%s/%s = %s\n", family, dev, name, sysctls[key])
+		}
+	}
+
+	for _, i := range networkCfg.Interfaces {
+		addDevice("_v"+i.Name, i.DisableIPv6, i.Sysctls)
+	}
+
+	for _, b := range networkCfg.Bonds {
+		addDevice("_v"+b.Name, b.DisableIPv6, b.Sysctls)
+	}
+
+	for _, v := range networkCfg.VLANs {
+		addDevice(v.Name, v.DisableIPv6, v.Sysctls)
+	}
+
+	return ret.String()
+}
+
+// getRemovedSysctls returns the sysctl keys set by the old drop-in contents but not by the new ones.
+func getRemovedSysctls(oldContents string, newContents string) []string {
+	keys := func(contents string) []string {
+		ret := []string{}
+
+		for line := range strings.Lines(contents) {
+			key, _, ok := strings.Cut(strings.TrimPrefix(line, "-"), " = ")
+			if ok {
+				ret = append(ret, key)
+			}
+		}
+
+		return ret
+	}
+
+	newKeys := keys(newContents)
+	removed := []string{}
+
+	for _, key := range keys(oldContents) {
+		if !slices.Contains(newKeys, key) {
+			removed = append(removed, key)
+		}
+	}
+
+	return removed
+}
+
+// resetSysctl resets a per-device sysctl key to the value of the kernel's "default" pseudo-device.
+// Errors are ignored as the device may no longer exist.
+func resetSysctl(key string) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 5 {
+		return
+	}
+
+	// #nosec G304
+	value, err := os.ReadFile(filepath.Join("/proc/sys", parts[0], parts[1], parts[2], "default", parts[4]))
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(filepath.Join("/proc/sys", key), value, 0o644)
+}
+
+// networkdConfigMatches checks if the provided files are exactly those currently present in /run/systemd/network/.
+func networkdConfigMatches(cfgs []NetworkdConfigFile) bool {
+	entries, err := os.ReadDir(SystemdNetworkConfigPath)
+	if err != nil || len(entries) != len(cfgs) {
+		return false
+	}
+
+	for _, cfg := range cfgs {
+		if !fileContentsMatch(filepath.Join(SystemdNetworkConfigPath, cfg.Name), cfg.Contents) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fileContentsMatch checks if the file exists with the provided contents. An empty string matches a missing file.
+func fileContentsMatch(path string, contents string) bool {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Is(err, os.ErrNotExist) && contents == ""
+	}
+
+	return string(existing) == contents
 }
 
 // waitForUdevInterfaceRename waits up to a provided timeout for udev to pickup and process
@@ -1087,87 +2081,63 @@ func waitForUdevInterfaceRename(ctx context.Context, expectedInterfaces []expPhy
 	}
 }
 
-// waitForNetworkOnline waits up to a provided timeout for configured network interfaces,
-// bonds, and vlans to configure their IP address(es) and come online.
-func waitForNetworkOnline(ctx context.Context, networkCfg *api.SystemNetworkConfig, timeout time.Duration) error {
-	isOnline := func(name string) (bool, bool) {
-		output, err := subprocess.RunCommandContext(ctx, "networkctl", "status", resolveBridge(name))
-		if err != nil {
-			return false, true
-		}
-
-		return strings.Contains(output, "Online state: online"), strings.Contains(output, "Required For Online: yes")
-	}
-
-	hasAtLeastOneConfiguredIP := func(iface string) bool {
-		ips, _ := GetIPAddresses(ctx, iface)
-
-		return len(ips) > 0
-	}
-
-	endTime := time.Now().Add(timeout)
-
-	devicesToCheck := []string{}
+// setDegradedDevices records the required devices that never came online, clearing the degraded state if there are none.
+func setDegradedDevices(networkState *api.SystemNetworkState, offlineDevices []string) {
+	networkState.Degraded = len(offlineDevices) > 0
+	networkState.DegradedDevices = offlineDevices
+}
 
-	needIPv6Delay := false
+// waitForNetworkOnline waits up to a provided timeout for configured network interfaces,
+// bonds, and vlans to configure their IP address(es) and come online. On timeout, the
+// devices that never came online are returned alongside the error.
+func waitForNetworkOnline(ctx context.Context, networkCfg *api.SystemNetworkConfig, timeout time.Duration) ([]string, error) {
+	// Allow for the longest carrier delay on top of the requested timeout. Devices skipping the
+	// online check don't need to be accounted for.
+	carrierDelays := []string{}
 
 	for _, i := range networkCfg.Interfaces {
-		if len(i.Addresses) == 0 {
-			continue
+		if !i.SkipOnlineCheck {
+			carrierDelays = append(carrierDelays, i.CarrierDelay)
 		}
-
-		if slices.Contains([]string{"ipv6", "both"}, i.RequiredForOnline) {
-			needIPv6Delay = true
-		}
-
-		devicesToCheck = append(devicesToCheck, i.Name)
 	}
 
 	for _, b := range networkCfg.Bonds {
-		if len(b.Addresses) == 0 {
-			continue
+		if !b.SkipOnlineCheck {
+			carrierDelays = append(carrierDelays, b.CarrierDelay)
 		}
+	}
 
-		if slices.Contains([]string{"ipv6", "both"}, b.RequiredForOnline) {
-			needIPv6Delay = true
-		}
+	var maxCarrierDelay time.Duration
 
-		devicesToCheck = append(devicesToCheck, b.Name)
+	for _, carrierDelay := range carrierDelays {
+		delay, err := time.ParseDuration(carrierDelay)
+		if err == nil && delay > maxCarrierDelay {
+			maxCarrierDelay = delay
+		}
 	}
 
-	for _, v := range networkCfg.VLANs {
-		if len(v.Addresses) == 0 {
-			continue
-		}
+	endTime := time.Now().Add(timeout + maxCarrierDelay)
 
-		if slices.Contains([]string{"ipv6", "both"}, v.RequiredForOnline) {
-			needIPv6Delay = true
-		}
+	devicesToCheck := getDevicesRequiredForOnline(networkCfg)
 
-		devicesToCheck = append(devicesToCheck, v.Name)
-	}
+	needIPv6Delay := false
 
-	for {
-		if time.Now().After(endTime) {
-			return errors.New("timed out waiting for network to come online")
+	for _, family := range devicesToCheck {
+		if family == "ipv6" || family == "both" {
+			needIPv6Delay = true
 		}
+	}
 
-		allDevicesOnline := true
-
-		for _, name := range devicesToCheck {
-			online, requiredOnline := isOnline(name)
-			if !requiredOnline {
-				continue
-			}
+	carrierDevices := getDevicesRequiringCarrier(networkCfg)
 
-			if !online || !hasAtLeastOneConfiguredIP(name) {
-				allDevicesOnline = false
+	for {
+		offline := getOfflineDevices(ctx, devicesToCheck, carrierDevices, networkCfg.OnlineGroups)
 
-				break
-			}
+		if time.Now().After(endTime) {
+			return offline, errors.New("timed out waiting for network to come online")
 		}
 
-		if allDevicesOnline {
+		if len(offline) == 0 {
 			if needIPv6Delay {
 				// Even with the interface configured to require IPv6
 				// family connectivity, networkd will sometimes mark the interface as
@@ -1178,32 +2148,249 @@ func waitForNetworkOnline(ctx context.Context, networkCfg *api.SystemNetworkConf
 				time.Sleep(3 * time.Second)
 			}
 
-			return nil
+			return nil, nil
 		}
 
 		time.Sleep(500 * time.Millisecond)
 	}
 }
 
-// waitForDNS waits up to a provided timeout for the system to be able to resolve DNS records.
-func waitForDNS(ctx context.Context, timeout time.Duration) error {
-	endTime := time.Now().Add(timeout)
-	resolver := net.Resolver{}
+// getDevicesRequiredForOnline returns a map of the devices required for the network to be online to the
+// address family they require.
+func getDevicesRequiredForOnline(networkCfg *api.SystemNetworkConfig) map[string]string {
+	devices := map[string]string{}
 
-	for {
-		if time.Now().After(endTime) {
-			return errors.New("timed out waiting for DNS to respond")
+	addDevice := func(name string, addresses []string, requiredForOnline string, skipOnlineCheck bool) {
+		if len(addresses) == 0 || skipOnlineCheck || slices.Contains(addresses, "link-local") {
+			return
 		}
 
-		// Attempt to resolve linuxcontainers.org to see if the DNS server is functional.
-		_, err := resolver.LookupIPAddr(ctx, "linuxcontainers.org")
-		if err == nil {
-			// Valid response received from the DNS server.
-			return nil
-		}
+		devices[name] = requiredFamilyForOnline(requiredForOnline)
+	}
 
-		var dnsErr *net.DNSError
-		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+	for _, i := range networkCfg.Interfaces {
+		addDevice(i.Name, i.Addresses, i.RequiredForOnline, i.SkipOnlineCheck)
+	}
+
+	for _, b := range networkCfg.Bonds {
+		addDevice(b.Name, b.Addresses, b.RequiredForOnline, b.SkipOnlineCheck)
+	}
+
+	for _, t := range networkCfg.Teams {
+		addDevice(t.Name, t.Addresses, t.RequiredForOnline, t.SkipOnlineCheck)
+	}
+
+	for _, v := range networkCfg.VLANs {
+		addDevice(v.Name, v.Addresses, v.RequiredForOnline, v.SkipOnlineCheck)
+	}
+
+	for _, v := range networkCfg.IPVLANs {
+		addDevice(v.Name, v.Addresses, v.RequiredForOnline, v.SkipOnlineCheck)
+	}
+
+	return devices
+}
+
+// GetOfflineDevices returns the devices required for the network to be online which currently aren't.
+func GetOfflineDevices(ctx context.Context, networkCfg *api.SystemNetworkConfig) []string {
+	return getOfflineDevices(ctx, getDevicesRequiredForOnline(networkCfg), getDevicesRequiringCarrier(networkCfg), networkCfg.OnlineGroups)
+}
+
+// getDevicesRequiringCarrier returns a map of the devices whose online state doesn't follow that of their uplink
+// to the uplink device that must also have carrier. This is the case of 802.3ad bonds using min_links, as their bridge
+// keeps its carrier through the veth once the bond has lost it.
+func getDevicesRequiringCarrier(networkCfg *api.SystemNetworkConfig) map[string]string {
+	devices := map[string]string{}
+
+	for _, b := range networkCfg.Bonds {
+		if b.Mode == "802.3ad" && b.MinLinks > 0 {
+			devices[b.Name] = "_b" + b.Name
+		}
+	}
+
+	return devices
+}
+
+// getOfflineDevices returns the sorted list of the provided devices which currently aren't online. Members of
+// an online group aren't reported as long as another member of the group is online.
+func getOfflineDevices(ctx context.Context, devices map[string]string, carrierDevices map[string]string, groups []api.SystemNetworkOnlineGroup) []string {
+	offline := []string{}
+
+	// Query the state of all devices at once, rather than once per device.
+	links, _ := getNetworkctlLinks(ctx)
+	addresses, _ := getAllIPAddresses(ctx)
+
+	for name, family := range devices {
+		dev := resolveBridge(name)
+
+		// A device unknown to networkd is treated as required, but not online.
+		link, ok := links[dev]
+		if ok && !link.RequiredForOnline {
+			continue
+		}
+
+		if link.OnlineState != "online" || !hasAddressForFamily(addresses[dev], family) {
+			offline = append(offline, name)
+
+			continue
+		}
+
+		// Bonds attached to their bridge report a carrier as enslaved.
+		uplink, ok := carrierDevices[name]
+		if ok && !slices.Contains([]string{"carrier", "enslaved"}, links[uplink].CarrierState) {
+			offline = append(offline, name)
+		}
+	}
+
+	offline = filterOnlineGroups(offline, devices, groups)
+
+	slices.Sort(offline)
+
+	return offline
+}
+
+// filterOnlineGroups removes the offline members of online groups having at least one member online.
+// Only members that are required for online count, as the others are never checked.
+func filterOnlineGroups(offline []string, devices map[string]string, groups []api.SystemNetworkOnlineGroup) []string {
+	for _, group := range groups {
+		satisfied := slices.ContainsFunc(group.Members, func(member string) bool {
+			_, required := devices[member]
+
+			return required && !slices.Contains(offline, member)
+		})
+
+		if satisfied {
+			offline = slices.DeleteFunc(offline, func(name string) bool { return slices.Contains(group.Members, name) })
+		}
+	}
+
+	return offline
+}
+
+// networkctlLink holds the subset of a link's networkctl JSON state that we care about.
+type networkctlLink struct {
+	Name              string `json:"Name"`              //nolint:tagliatelle
+	CarrierState      string `json:"CarrierState"`      //nolint:tagliatelle
+	OnlineState       string `json:"OnlineState"`       //nolint:tagliatelle
+	RequiredForOnline bool   `json:"RequiredForOnline"` //nolint:tagliatelle
+}
+
+// getNetworkctlLinks returns the networkctl state of all links, indexed by link name.
+func getNetworkctlLinks(ctx context.Context) (map[string]networkctlLink, error) {
+	output, err := subprocess.RunCommandContext(ctx, "networkctl", "list", "--json=short")
+	if err != nil {
+		return nil, err
+	}
+
+	type linksStruct struct {
+		Interfaces []networkctlLink `json:"Interfaces"` //nolint:tagliatelle
+	}
+
+	links := linksStruct{}
+
+	err = json.Unmarshal([]byte(output), &links)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]networkctlLink, len(links.Interfaces))
+	for _, link := range links.Interfaces {
+		ret[link.Name] = link
+	}
+
+	return ret, nil
+}
+
+// ipLink holds the subset of a link's "ip -json" output that we care about.
+type ipLink struct {
+	IfName    string `json:"ifname"`
+	MTU       int    `json:"mtu"`
+	OperState string `json:"operstate"`
+	Address   string `json:"address"`
+	PermAddr  string `json:"permaddr"`
+	AddrInfo  []struct {
+		Family    string `json:"family"`
+		Local     string `json:"local"`
+		PrefixLen int    `json:"prefixlen"`
+	} `json:"addr_info"`
+}
+
+// getIPLinks returns the address details of all links as reported by "ip -json address show".
+func getIPLinks(ctx context.Context) ([]ipLink, error) {
+	output, err := subprocess.RunCommandContext(ctx, "ip", "-json", "address", "show")
+	if err != nil {
+		return nil, err
+	}
+
+	links := []ipLink{}
+
+	err = json.Unmarshal([]byte(output), &links)
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// getPermanentHwaddr returns the permanent MAC address of a link, falling back to its current one.
+func getPermanentHwaddr(link ipLink) string {
+	if link.PermAddr != "" {
+		return link.PermAddr
+	}
+
+	return link.Address
+}
+
+// getAllIPAddresses returns any non-link-local address for all links, indexed by link name.
+func getAllIPAddresses(ctx context.Context) (map[string][]string, error) {
+	links, err := getIPLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string][]string, len(links))
+
+	for _, link := range links {
+		for _, addr := range link.AddrInfo {
+			if addr.Family != "inet" && addr.Family != "inet6" {
+				continue
+			}
+
+			if isLinkLocalAddress(addr.Local) {
+				continue
+			}
+
+			ret[link.IfName] = append(ret[link.IfName], addr.Local)
+		}
+	}
+
+	return ret, nil
+}
+
+// isLinkLocalAddress returns true if the provided address is an IPv4 or IPv6 link-local address.
+func isLinkLocalAddress(addr string) bool {
+	return strings.HasPrefix(addr, "169.254.") || strings.HasPrefix(addr, "fe80:")
+}
+
+// waitForDNS waits up to a provided timeout for the system to be able to resolve DNS records.
+func waitForDNS(ctx context.Context, timeout time.Duration) error {
+	endTime := time.Now().Add(timeout)
+	resolver := net.Resolver{}
+
+	for {
+		if time.Now().After(endTime) {
+			return errors.New("timed out waiting for DNS to respond")
+		}
+
+		// Attempt to resolve linuxcontainers.org to see if the DNS server is functional.
+		_, err := resolver.LookupIPAddr(ctx, "linuxcontainers.org")
+		if err == nil {
+			// Valid response received from the DNS server.
+			return nil
+		}
+
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
 			// NXDOMAIN received from the DNS server.
 			// We still consider this as functional as we are talking to a live (though likely isolated) DNS server.
 			return nil
@@ -1254,8 +2441,8 @@ func waitForSystemdTimesyncd(ctx context.Context, timeout time.Duration) error {
 
 // generateLinkFileContents generates the contents of systemd.link files. Returns an array of ConfigFile structs.
 // https://www.freedesktop.org/software/systemd/man/latest/systemd.link.html
-func generateLinkFileContents(networkCfg api.SystemNetworkConfig) []networkdConfigFile {
-	ret := []networkdConfigFile{}
+func generateLinkFileContents(networkCfg api.SystemNetworkConfig) []NetworkdConfigFile {
+	ret := []NetworkdConfigFile{}
 
 	generateEthernet := func(s *api.SystemNetworkEthernet) string {
 		if s == nil {
@@ -1279,6 +2466,44 @@ func generateLinkFileContents(networkCfg api.SystemNetworkConfig) []networkdConf
 			segments = append(segments, "TCP6SegmentationOffload=false")
 		}
 
+		if s.DisableRxFlowControl {
+			segments = append(segments, "RxFlowControl=false")
+		}
+
+		if s.DisableTxFlowControl {
+			segments = append(segments, "TxFlowControl=false")
+		}
+
+		if s.CombinedChannels > 0 {
+			segments = append(segments, fmt.Sprintf("CombinedChannels=%d", s.CombinedChannels))
+		}
+
+		if s.RxChannels > 0 {
+			segments = append(segments, fmt.Sprintf("RxChannels=%d", s.RxChannels))
+		}
+
+		if s.TxChannels > 0 {
+			segments = append(segments, fmt.Sprintf("TxChannels=%d", s.TxChannels))
+		}
+
+		if s.RxBufferSize > 0 {
+			segments = append(segments, fmt.Sprintf("RxBufferSize=%d", s.RxBufferSize))
+		}
+
+		if s.TxBufferSize > 0 {
+			segments = append(segments, fmt.Sprintf("TxBufferSize=%d", s.TxBufferSize))
+		}
+
+		rxCoalesce, err := time.ParseDuration(s.RxCoalesce)
+		if err == nil {
+			segments = append(segments, fmt.Sprintf("RxCoalesceSec=%dus", rxCoalesce.Microseconds()))
+		}
+
+		txCoalesce, err := time.ParseDuration(s.TxCoalesce)
+		if err == nil {
+			segments = append(segments, fmt.Sprintf("TxCoalesceSec=%dus", txCoalesce.Microseconds()))
+		}
+
 		if s.WakeOnLAN {
 			if len(s.WakeOnLANModes) > 0 {
 				for _, mode := range s.WakeOnLANModes {
@@ -1309,54 +2534,88 @@ Enable=false`
 	}
 
 	for _, i := range networkCfg.Interfaces {
+		// Default to a random MAC address, unless an explicit address or policy is configured. Management
+		// interfaces keep their permanent address as they're not hidden behind a veth device.
+		macString := "MACAddressPolicy=random"
+		if i.Management {
+			macString = "MACAddressPolicy=none"
+		}
+
+		if i.MACAddress != "" {
+			macString = "MACAddress=" + i.MACAddress
+		} else if i.MACAddressPolicy != "" {
+			macString = "MACAddressPolicy=" + i.MACAddressPolicy
+		}
+
+		altNames := ""
+		if len(i.AlternativeNames) > 0 {
+			altNames = "AlternativeNames=" + strings.Join(i.AlternativeNames, " ") + "\n"
+		}
+
 		strippedHwaddr := strings.ToLower(strings.ReplaceAll(i.Hwaddr, ":", ""))
-		ret = append(ret, networkdConfigFile{
-			Name: fmt.Sprintf("00-_p%s.link", strippedHwaddr),
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_p%s.link", LinkFilePriority, strippedHwaddr),
 			Contents: fmt.Sprintf(`[Match]
 PermanentMACAddress=%s
 
 [Link]
-MACAddressPolicy=random
+%s
 NamePolicy=
-Name=_p%s
-%s`, i.Hwaddr, strippedHwaddr, generateEthernet(i.Ethernet)),
+Name=%s
+%s%s`, i.Hwaddr, macString, getInterfaceDevice(i), altNames, generateEthernet(i.Ethernet)),
 		})
 	}
 
-	for _, b := range networkCfg.Bonds {
-		for _, member := range b.Members {
-			strippedHwaddr := strings.ToLower(strings.ReplaceAll(member, ":", ""))
-			ret = append(ret, networkdConfigFile{
-				Name: fmt.Sprintf("01-_p%s.link", strippedHwaddr),
-				Contents: fmt.Sprintf(`[Match]
+	// Members of bonds and teams are only renamed, their MAC address being managed by the aggregation.
+	generateMemberLink := func(member string, ethernet *api.SystemNetworkEthernet) NetworkdConfigFile {
+		strippedHwaddr := strings.ToLower(strings.ReplaceAll(member, ":", ""))
+
+		return NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_p%s.link", LinkFilePriority+1, strippedHwaddr),
+			Contents: fmt.Sprintf(`[Match]
 PermanentMACAddress=%s
 
 [Link]
 NamePolicy=
 Name=_p%s
-%s`, member, strippedHwaddr, generateEthernet(b.Ethernet)),
-			})
+%s`, member, strippedHwaddr, generateEthernet(ethernet)),
+		}
+	}
+
+	for _, b := range networkCfg.Bonds {
+		for _, member := range b.Members {
+			ret = append(ret, generateMemberLink(member, b.Ethernet))
+		}
+	}
+
+	for _, t := range networkCfg.Teams {
+		for _, member := range t.Members {
+			ret = append(ret, generateMemberLink(member, nil))
 		}
 	}
 
 	return ret
 }
 
-// generateNetdevFileContents generates the contents of systemd.netdev files. Returns an array of networkdConfigFile structs.
+// generateNetdevFileContents generates the contents of systemd.netdev files. Returns an array of NetworkdConfigFile structs.
 // https://www.freedesktop.org/software/systemd/man/latest/systemd.netdev.html
-func generateNetdevFileContents(networkCfg api.SystemNetworkConfig) []networkdConfigFile {
-	ret := make([]networkdConfigFile, 0, 2*len(networkCfg.Interfaces)+3*len(networkCfg.Bonds)+len(networkCfg.Wireguard))
+func generateNetdevFileContents(networkCfg api.SystemNetworkConfig) []NetworkdConfigFile {
+	ret := make([]NetworkdConfigFile, 0, 2*len(networkCfg.Interfaces)+3*len(networkCfg.Bonds)+len(networkCfg.Wireguard))
 
 	// Create bridge and veth devices for each interface.
 	for _, i := range networkCfg.Interfaces {
+		if i.Management {
+			continue
+		}
+
 		mtuString := ""
 		if i.MTU != 0 {
 			mtuString = fmt.Sprintf("MTUBytes=%d", i.MTU)
 		}
 
 		// Bridge.
-		ret = append(ret, networkdConfigFile{
-			Name: fmt.Sprintf("10-%s.netdev", i.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+interfaceFileOffset+i.Priority, i.Name),
 			Contents: fmt.Sprintf(`[NetDev]
 Name=%s
 Kind=bridge
@@ -1364,13 +2623,12 @@ Kind=bridge
 
 [Bridge]
 VLANFiltering=true
-`, i.Name, mtuString),
+%s`, i.Name, mtuString, generateBridgeContents(i.DefaultPVID, i.VLANProtocol, i.STP)),
 		})
 
 		// veth.
-		strippedHwaddr := strings.ToLower(strings.ReplaceAll(i.Hwaddr, ":", ""))
-		ret = append(ret, networkdConfigFile{
-			Name: fmt.Sprintf("10-_v%s.netdev", i.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_v%s.netdev", NetdevFilePriority+interfaceFileOffset+i.Priority, i.Name),
 			Contents: fmt.Sprintf(`[NetDev]
 Name=_v%s
 Kind=veth
@@ -1378,8 +2636,8 @@ MACAddress=%s
 %s
 
 [Peer]
-Name=_i%s
-`, i.Name, i.Hwaddr, mtuString, strippedHwaddr),
+Name=%s
+`, i.Name, i.Hwaddr, mtuString, getVethPeerName(i)),
 		})
 	}
 
@@ -1398,11 +2656,27 @@ Name=_i%s
 			if b.Mode == "802.3ad" {
 				_, _ = sbMode.WriteString("\nTransmitHashPolicy=layer3+4")
 				_, _ = sbMode.WriteString("\nLACPTransmitRate=fast")
+
+				if b.MinLinks > 0 {
+					_, _ = fmt.Fprintf(&sbMode, "\nMinLinks=%d", b.MinLinks)
+				}
+
+				if b.AdActorSystemPriority > 0 {
+					_, _ = fmt.Fprintf(&sbMode, "\nAdActorSystemPriority=%d", b.AdActorSystemPriority)
+				}
+
+				if b.AdUserPortKey > 0 {
+					_, _ = fmt.Fprintf(&sbMode, "\nAdUserPortKey=%d", b.AdUserPortKey)
+				}
+
+				if b.AdActorSystem != "" {
+					_, _ = fmt.Fprintf(&sbMode, "\nAdActorSystem=%s", strings.ToLower(b.AdActorSystem))
+				}
 			}
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name: fmt.Sprintf("11-_b%s.netdev", b.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_b%s.netdev", NetdevFilePriority+bondFileOffset+b.Priority, b.Name),
 			Contents: fmt.Sprintf(`[NetDev]
 Name=_b%s
 Kind=bond
@@ -1414,8 +2688,8 @@ Kind=bond
 		})
 
 		// Bridge.
-		ret = append(ret, networkdConfigFile{
-			Name: fmt.Sprintf("11-%s.netdev", b.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+bondFileOffset+b.Priority, b.Name),
 			Contents: fmt.Sprintf(`[NetDev]
 Name=%s
 Kind=bridge
@@ -1423,7 +2697,7 @@ Kind=bridge
 
 [Bridge]
 VLANFiltering=true
-`, b.Name, mtuString),
+%s`, b.Name, mtuString, generateBridgeContents(b.DefaultPVID, b.VLANProtocol, b.STP)),
 		})
 
 		// veth.
@@ -1433,8 +2707,8 @@ VLANFiltering=true
 		}
 
 		strippedHwaddr := strings.ToLower(strings.ReplaceAll(bondMacAddr, ":", ""))
-		ret = append(ret, networkdConfigFile{
-			Name: fmt.Sprintf("11-_v%s.netdev", b.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_v%s.netdev", NetdevFilePriority+bondFileOffset+b.Priority, b.Name),
 			Contents: fmt.Sprintf(`[NetDev]
 Name=_v%s
 Kind=veth
@@ -1447,6 +2721,47 @@ Name=_i%s
 		})
 	}
 
+	// Create bridge and veth devices for each team. The team device itself is created by teamd.
+	for _, t := range networkCfg.Teams {
+		mtuString := ""
+		if t.MTU != 0 {
+			mtuString = fmt.Sprintf("MTUBytes=%d", t.MTU)
+		}
+
+		// Bridge.
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+teamFileOffset, t.Name),
+			Contents: fmt.Sprintf(`[NetDev]
+Name=%s
+Kind=bridge
+%s
+
+[Bridge]
+VLANFiltering=true
+%s`, t.Name, mtuString, generateBridgeContents(nil, "", false)),
+		})
+
+		// veth.
+		teamMacAddr := t.Hwaddr
+		if teamMacAddr == "" {
+			teamMacAddr = t.Members[0]
+		}
+
+		strippedHwaddr := strings.ToLower(strings.ReplaceAll(teamMacAddr, ":", ""))
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_v%s.netdev", NetdevFilePriority+teamFileOffset, t.Name),
+			Contents: fmt.Sprintf(`[NetDev]
+Name=_v%s
+Kind=veth
+MACAddress=%s
+%s
+
+[Peer]
+Name=_i%s
+`, t.Name, teamMacAddr, mtuString, strippedHwaddr),
+		})
+	}
+
 	// Create vlans.
 	for _, v := range networkCfg.VLANs {
 		mtuString := ""
@@ -1454,16 +2769,35 @@ Name=_i%s
 			mtuString = fmt.Sprintf("MTUBytes=%d", v.MTU)
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name: fmt.Sprintf("12-%s.netdev", v.Name),
+		macString := ""
+		if v.Hwaddr != "" {
+			macString = "MACAddress=" + v.Hwaddr + "\n"
+		}
+
+		// Match the VLAN protocol used by the parent's bridge.
+		vlanOptions := fmt.Sprintf("Id=%d\n", v.ID)
+
+		for _, i := range networkCfg.Interfaces {
+			if i.Name == v.Parent && i.VLANProtocol != "" {
+				vlanOptions += "Protocol=" + i.VLANProtocol + "\n"
+			}
+		}
+
+		for _, b := range networkCfg.Bonds {
+			if b.Name == v.Parent && b.VLANProtocol != "" {
+				vlanOptions += "Protocol=" + b.VLANProtocol + "\n"
+			}
+		}
+
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+vlanFileOffset+v.Priority, v.Name),
 			Contents: fmt.Sprintf(`[NetDev]
 Name=%s
 Kind=vlan
-%s
+%s%s
 
 [VLAN]
-Id=%d
-`, v.Name, mtuString, v.ID),
+%s`, v.Name, macString, mtuString, vlanOptions),
 		})
 	}
 
@@ -1517,65 +2851,267 @@ PublicKey=%s
 `, peer.PublicKey, options.String())
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("13-%s.netdev", w.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+wireguardFileOffset, w.Name),
 			Contents: cfgBuffer.String(),
 		})
 	}
 
-	return ret
-}
-
-// generateNetworkFileContents generates the contents of systemd.network files. Returns an array of networkdConfigFile structs.
-// https://www.freedesktop.org/software/systemd/man/latest/systemd.network.html
-func generateNetworkFileContents(networkCfg api.SystemNetworkConfig) []networkdConfigFile {
-	ret := []networkdConfigFile{}
-
-	// Create networks for each interface and its bridge.
-	for _, i := range networkCfg.Interfaces {
-		// User side of veth device.
-		cfgString := fmt.Sprintf(`[Match]
-Name=_v%s
+	// Create tunnels, with bridge and veth devices for each GRETAP tunnel.
+	for _, t := range networkCfg.Tunnels {
+		mtuString := ""
+		if t.MTU != 0 {
+			mtuString = fmt.Sprintf("MTUBytes=%d", t.MTU)
+		}
 
-[Link]
-%s
+		var tunnelOptions strings.Builder
 
-[DHCP]
-ClientIdentifier=mac
-RouteMetric=100
-UseMTU=true
+		_, _ = tunnelOptions.WriteString("Independent=true\n")
 
-[DHCPv6]
-WithoutRA=solicit
+		if t.Local != "" {
+			_, _ = fmt.Fprintf(&tunnelOptions, "Local=%s\n", t.Local)
+		}
 
-[Network]
-%s`, i.Name, generateLinkSectionContents(i.Addresses, i.RequiredForOnline), generateNetworkSectionContents(i.Name, networkCfg.VLANs, networkCfg.DNS, networkCfg.Time))
+		_, _ = fmt.Fprintf(&tunnelOptions, "Remote=%s\n", t.Remote)
 
-		cfgString += processAddresses(i.Addresses)
+		if t.Key != 0 {
+			_, _ = fmt.Fprintf(&tunnelOptions, "Key=%d\n", t.Key)
+		}
 
-		if len(i.Routes) > 0 {
-			cfgString += processRoutes(i.Routes)
+		if t.TTL != 0 {
+			_, _ = fmt.Fprintf(&tunnelOptions, "TTL=%d\n", t.TTL)
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("20-_v%s.network", i.Name),
-			Contents: cfgString,
-		})
+		if t.Kind != "gretap" {
+			ret = append(ret, NetworkdConfigFile{
+				Name: fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+tunnelFileOffset, t.Name),
+				Contents: fmt.Sprintf(`[NetDev]
+Name=%s
+Kind=%s
+%s
 
-		// Bridge side of veth device.
-		strippedHwaddr := strings.ToLower(strings.ReplaceAll(i.Hwaddr, ":", ""))
-		cfgString = fmt.Sprintf(`[Match]
-Name=_i%s
+[Tunnel]
+%s`, t.Name, t.Kind, mtuString, tunnelOptions.String()),
+			})
 
-[Network]
-Bridge=%s
-`, strippedHwaddr, i.Name)
+			continue
+		}
 
-		cfgString += generateVLANContents(i.Name, i.VLANTags, networkCfg.VLANs)
+		// Tunnel.
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_t%s.netdev", NetdevFilePriority+tunnelFileOffset, t.Name),
+			Contents: fmt.Sprintf(`[NetDev]
+Name=_t%s
+Kind=gretap
+%s
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("20-_i%s.network", strippedHwaddr),
-			Contents: cfgString,
+[Tunnel]
+%s`, t.Name, mtuString, tunnelOptions.String()),
+		})
+
+		// Bridge.
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+tunnelFileOffset, t.Name),
+			Contents: fmt.Sprintf(`[NetDev]
+Name=%s
+Kind=bridge
+%s
+
+[Bridge]
+VLANFiltering=true
+`, t.Name, mtuString),
+		})
+
+		// veth.
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_v%s.netdev", NetdevFilePriority+tunnelFileOffset, t.Name),
+			Contents: fmt.Sprintf(`[NetDev]
+Name=_v%s
+Kind=veth
+%s
+
+[Peer]
+Name=_i%s
+`, t.Name, mtuString, t.Name),
+		})
+	}
+
+	// Create VRF devices.
+	for _, v := range networkCfg.VRFs {
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+vrfFileOffset, v.Name),
+			Contents: fmt.Sprintf(`[NetDev]
+Name=%s
+Kind=vrf
+
+[VRF]
+Table=%d
+`, v.Name, v.Table),
+		})
+	}
+
+	// Create IPVLANs.
+	for _, v := range networkCfg.IPVLANs {
+		mtuString := ""
+		if v.MTU != 0 {
+			mtuString = fmt.Sprintf("MTUBytes=%d", v.MTU)
+		}
+
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.netdev", NetdevFilePriority+ipvlanFileOffset, v.Name),
+			Contents: fmt.Sprintf(`[NetDev]
+Name=%s
+Kind=ipvlan
+%s
+
+[IPVLAN]
+Mode=%s
+`, v.Name, mtuString, strings.ToUpper(v.Mode)),
+		})
+	}
+
+	return ret
+}
+
+// generateNetworkFileContents generates the contents of systemd.network files. Returns an array of NetworkdConfigFile structs.
+// https://www.freedesktop.org/software/systemd/man/latest/systemd.network.html
+func generateNetworkFileContents(networkCfg api.SystemNetworkConfig) []NetworkdConfigFile {
+	ret := []NetworkdConfigFile{}
+
+	pdUplink := getPrefixDelegationUplink(networkCfg)
+	defaultRouteSource := getDefaultRouteSource(networkCfg)
+
+	// Leave any ignored devices alone, taking precedence over all other files.
+	hwaddrhRegex := regexp.MustCompile(`^[[:xdigit:]]{2}:[[:xdigit:]]{2}:[[:xdigit:]]{2}:[[:xdigit:]]{2}:[[:xdigit:]]{2}:[[:xdigit:]]{2}$`)
+
+	for index, pattern := range networkCfg.Ignore {
+		match := "Name=" + pattern
+		if hwaddrhRegex.MatchString(pattern) {
+			match = "PermanentMACAddress=" + pattern
+		}
+
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("00-ignore%d.network", index),
+			Contents: fmt.Sprintf(`[Match]
+%s
+
+[Link]
+Unmanaged=yes
+`, match),
+		})
+	}
+
+	// Create networks for each interface and its bridge.
+	for _, i := range networkCfg.Interfaces {
+		// User side of veth device.
+		cfgString := fmt.Sprintf(`[Match]
+Name=_v%s
+
+[Link]
+%s
+
+[DHCP]
+ClientIdentifier=mac
+RouteMetric=%d
+UseMTU=true
+
+[DHCPv6]
+WithoutRA=solicit
+
+[Network]
+%s`, i.Name, generateLinkSectionContents(i.Addresses, i.RequiredForOnline), cmp.Or(getRouteMetric(i.LinkType, i.RouteMetric), 100), generateNetworkSectionContents(i.Name, networkCfg.VLANs, networkCfg.IPVLANs, networkCfg.DNS, i.DNS, networkCfg.Time, i.NTPServers))
+
+		if i.VRF != "" {
+			cfgString += "VRF=" + i.VRF + "\n"
+		}
+
+		if i.IPMasquerade != "" {
+			cfgString += "IPMasquerade=" + i.IPMasquerade + "\n"
+		}
+
+		if i.KeepConfiguration != "" {
+			cfgString += "KeepConfiguration=" + i.KeepConfiguration + "\n"
+		}
+
+		// Management interfaces configure the physical device directly.
+		if i.Management {
+			cfgString += fmt.Sprintf("LLDP=%s\nEmitLLDP=%s\n", strconv.FormatBool(i.LLDP), strconv.FormatBool(i.LLDP))
+			cfgString += generateCarrierDelayContents(i.CarrierDelay)
+		}
+
+		cfgString += generateIPForwardingContents(i.IPForwarding)
+		cfgString += processProxyARPNDP(i.IPv4ProxyARP, i.IPv6ProxyNDP, i.IPv6ProxyNDPAddresses)
+
+		if i.IPv6DAD != nil {
+			cfgString += fmt.Sprintf("IPv6DuplicateAddressDetection=%d\n", *i.IPv6DAD)
+		}
+
+		if i.IPv6AddressGenerationMode != "" {
+			cfgString += "IPv6LinkLocalAddressGenerationMode=" + i.IPv6AddressGenerationMode + "\n"
+		}
+
+		cfgString += generatePrefixDelegationNetworkContents(i.PrefixDelegation)
+		cfgString += processAddresses(getPrefixDelegationAddresses(i.PrefixDelegation, i.Addresses), i.IPv4LinkLocal, i.DisableIPv6, i.AddressOptions)
+
+		cfgString += generateDHCPv4Contents(i.IPv6OnlyMode, i.DHCPVendorClass, i.DHCPUserClass, acceptsDefaultRoute(defaultRouteSource, i.Name), i.DHCPUseRoutes == nil || *i.DHCPUseRoutes)
+		cfgString += generateDHCPFallbackContents(i.DHCPFallbackAddress)
+		cfgString += generateDHCPNTPContents(networkCfg.Time, i.Addresses)
+		cfgString += generateIPv6AcceptRAContents(i.IPv6AddressGenerationMode, i.Addresses, acceptsDefaultRoute(defaultRouteSource, i.Name))
+
+		if len(i.Routes) > 0 {
+			cfgString += processRoutes(i.Routes, getRouteMetric(i.LinkType, i.RouteMetric))
+		}
+
+		cfgString += generateNeighborContents(i.Neighbors)
+		cfgString += generatePrefixDelegationContents(i.PrefixDelegation, pdUplink)
+		cfgString += generateQoSContents(i.QoS)
+
+		if i.Management && i.MTU != 0 {
+			cfgString += fmt.Sprintf("[Link]\nMTUBytes=%d\n", i.MTU)
+		}
+
+		if i.Management {
+			cfgString += generatePromiscuousContents(i.Promiscuous, i.AllMulticast)
+		}
+
+		cfgString += generateExtraOptionsContents(i.ExtraOptions)
+
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-_v%s.network", NetworkFilePriority+interfaceFileOffset+i.Priority, i.Name),
+			Contents: cfgString,
+		})
+
+		if i.Management {
+			continue
+		}
+
+		// Bridge side of veth device. A detached peer is left out of the bridge, for another tool to use.
+		strippedHwaddr := strings.ToLower(strings.ReplaceAll(i.Hwaddr, ":", ""))
+		peerName := getVethPeerName(i)
+
+		if i.VethPeerDetached {
+			cfgString = fmt.Sprintf(`[Match]
+Name=%s
+
+[Network]
+LinkLocalAddressing=no
+ConfigureWithoutCarrier=yes
+`, peerName)
+		} else {
+			cfgString = fmt.Sprintf(`[Match]
+Name=%s
+
+[Network]
+Bridge=%s
+`, peerName, i.Name)
+
+			cfgString += generateVLANContents(i.Name, i.VLANTags, networkCfg.VLANs)
+			cfgString += generateBridgeFDBContents(i.BridgeFDB, "host")
+		}
+
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.network", NetworkFilePriority+interfaceFileOffset+i.Priority, peerName),
+			Contents: cfgString,
 		})
 
 		// Add underlying interface to bridge.
@@ -1586,16 +3122,20 @@ Name=_p%s
 LLDP=%s
 EmitLLDP=%s
 Bridge=%s
-`, strippedHwaddr, strconv.FormatBool(i.LLDP), strconv.FormatBool(i.LLDP), i.Name)
+%s`, strippedHwaddr, strconv.FormatBool(i.LLDP), strconv.FormatBool(i.LLDP), i.Name, generateCarrierDelayContents(i.CarrierDelay))
 
 		cfgString += generateVLANContents(i.Name, i.VLANTags, networkCfg.VLANs)
+		cfgString += generateBridgePortContents(i.BridgeCost, i.BridgePriority)
+		cfgString += generatePromiscuousContents(i.Promiscuous, i.AllMulticast)
+		cfgString += generateEgressRateLimitContents(i.EgressRateLimit)
+		cfgString += generateBridgeFDBContents(i.BridgeFDB, "uplink")
 
 		if i.MTU != 0 {
 			cfgString += fmt.Sprintf("[Link]\nMTUBytes=%d\n", i.MTU)
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("20-_p%s.network", strippedHwaddr),
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-_p%s.network", NetworkFilePriority+interfaceFileOffset+i.Priority, strippedHwaddr),
 			Contents: cfgString,
 		})
 
@@ -1612,8 +3152,8 @@ ConfigureWithoutCarrier=yes
 			cfgString += fmt.Sprintf("[Link]\nMTUBytes=%d\n", i.MTU)
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("20-%s.network", i.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.network", NetworkFilePriority+interfaceFileOffset+i.Priority, i.Name),
 			Contents: cfgString,
 		})
 	}
@@ -1629,23 +3169,57 @@ Name=_v%s
 
 [DHCP]
 ClientIdentifier=mac
-RouteMetric=100
+RouteMetric=%d
 UseMTU=true
 
 [DHCPv6]
 WithoutRA=solicit
 
 [Network]
-%s`, b.Name, generateLinkSectionContents(b.Addresses, b.RequiredForOnline), generateNetworkSectionContents(b.Name, networkCfg.VLANs, networkCfg.DNS, networkCfg.Time))
+%s`, b.Name, generateLinkSectionContents(b.Addresses, b.RequiredForOnline), cmp.Or(getRouteMetric(b.LinkType, b.RouteMetric), 100), generateNetworkSectionContents(b.Name, networkCfg.VLANs, networkCfg.IPVLANs, networkCfg.DNS, b.DNS, networkCfg.Time, b.NTPServers))
+
+		if b.VRF != "" {
+			cfgString += "VRF=" + b.VRF + "\n"
+		}
+
+		if b.IPMasquerade != "" {
+			cfgString += "IPMasquerade=" + b.IPMasquerade + "\n"
+		}
+
+		if b.KeepConfiguration != "" {
+			cfgString += "KeepConfiguration=" + b.KeepConfiguration + "\n"
+		}
+
+		cfgString += generateIPForwardingContents(b.IPForwarding)
+		cfgString += processProxyARPNDP(b.IPv4ProxyARP, b.IPv6ProxyNDP, b.IPv6ProxyNDPAddresses)
+
+		if b.IPv6DAD != nil {
+			cfgString += fmt.Sprintf("IPv6DuplicateAddressDetection=%d\n", *b.IPv6DAD)
+		}
 
-		cfgString += processAddresses(b.Addresses)
+		if b.IPv6AddressGenerationMode != "" {
+			cfgString += "IPv6LinkLocalAddressGenerationMode=" + b.IPv6AddressGenerationMode + "\n"
+		}
+
+		cfgString += generatePrefixDelegationNetworkContents(b.PrefixDelegation)
+		cfgString += processAddresses(getPrefixDelegationAddresses(b.PrefixDelegation, b.Addresses), b.IPv4LinkLocal, b.DisableIPv6, b.AddressOptions)
+
+		cfgString += generateDHCPv4Contents(b.IPv6OnlyMode, b.DHCPVendorClass, b.DHCPUserClass, acceptsDefaultRoute(defaultRouteSource, b.Name), b.DHCPUseRoutes == nil || *b.DHCPUseRoutes)
+		cfgString += generateDHCPFallbackContents(b.DHCPFallbackAddress)
+		cfgString += generateDHCPNTPContents(networkCfg.Time, b.Addresses)
+		cfgString += generateIPv6AcceptRAContents(b.IPv6AddressGenerationMode, b.Addresses, acceptsDefaultRoute(defaultRouteSource, b.Name))
 
 		if len(b.Routes) > 0 {
-			cfgString += processRoutes(b.Routes)
+			cfgString += processRoutes(b.Routes, getRouteMetric(b.LinkType, b.RouteMetric))
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("21-_v%s.network", b.Name),
+		cfgString += generateNeighborContents(b.Neighbors)
+		cfgString += generatePrefixDelegationContents(b.PrefixDelegation, pdUplink)
+		cfgString += generateQoSContents(b.QoS)
+		cfgString += generateExtraOptionsContents(b.ExtraOptions)
+
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-_v%s.network", NetworkFilePriority+bondFileOffset+b.Priority, b.Name),
 			Contents: cfgString,
 		})
 
@@ -1665,9 +3239,10 @@ Bridge=%s
 `, strippedHwaddr, b.Name)
 
 		cfgString += generateVLANContents(b.Name, b.VLANTags, networkCfg.VLANs)
+		cfgString += generateBridgeFDBContents(b.BridgeFDB, "host")
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("21-_i%s.network", strippedHwaddr),
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-_i%s.network", NetworkFilePriority+bondFileOffset+b.Priority, strippedHwaddr),
 			Contents: cfgString,
 		})
 
@@ -1682,9 +3257,13 @@ Bridge=%s
 `, b.Name, b.Name)
 
 		cfgString += generateVLANContents(b.Name, b.VLANTags, networkCfg.VLANs)
+		cfgString += generateBridgePortContents(b.BridgeCost, b.BridgePriority)
+		cfgString += generatePromiscuousContents(b.Promiscuous, b.AllMulticast)
+		cfgString += generateEgressRateLimitContents(b.EgressRateLimit)
+		cfgString += generateBridgeFDBContents(b.BridgeFDB, "uplink")
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("21-_b%s.network", b.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-_b%s.network", NetworkFilePriority+bondFileOffset+b.Priority, b.Name),
 			Contents: cfgString,
 		})
 
@@ -1697,17 +3276,17 @@ LinkLocalAddressing=no
 ConfigureWithoutCarrier=yes
 `, b.Name)
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("21-%s.network", b.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.network", NetworkFilePriority+bondFileOffset+b.Priority, b.Name),
 			Contents: cfgString,
 		})
 
-		// Bond members.
-		for index, member := range b.Members {
+		// Bond members, named after their MAC so reordering them doesn't rewrite their files.
+		for _, member := range b.Members {
 			memberStrippedHwaddr := strings.ToLower(strings.ReplaceAll(member, ":", ""))
 
-			ret = append(ret, networkdConfigFile{
-				Name: fmt.Sprintf("21-_b%s-dev%d.network", b.Name, index),
+			ret = append(ret, NetworkdConfigFile{
+				Name: fmt.Sprintf("%02d-_b%s-_p%s.network", NetworkFilePriority+bondFileOffset+b.Priority, b.Name, memberStrippedHwaddr),
 				Contents: fmt.Sprintf(`[Match]
 Name=_p%s
 
@@ -1715,174 +3294,1148 @@ Name=_p%s
 LLDP=%s
 EmitLLDP=%s
 Bond=_b%s
-`, memberStrippedHwaddr, strconv.FormatBool(b.LLDP), strconv.FormatBool(b.LLDP), b.Name),
+%s`, memberStrippedHwaddr, strconv.FormatBool(b.LLDP), strconv.FormatBool(b.LLDP), b.Name, generateCarrierDelayContents(b.CarrierDelay)),
 			})
 		}
 	}
 
-	// Create network for each VLAN.
-	for _, v := range networkCfg.VLANs {
+	// Create networks for each team and its bridge.
+	for _, t := range networkCfg.Teams {
+		// User side of veth device.
 		cfgString := fmt.Sprintf(`[Match]
-Name=%s
+Name=_v%s
 
 [Link]
 %s
 
 [DHCP]
 ClientIdentifier=mac
-RouteMetric=100
+RouteMetric=%d
 UseMTU=true
 
 [DHCPv6]
 WithoutRA=solicit
 
 [Network]
-%s`, v.Name, generateLinkSectionContents(v.Addresses, v.RequiredForOnline), generateNetworkSectionContents(v.Name, nil, networkCfg.DNS, networkCfg.Time))
+%s`, t.Name, generateLinkSectionContents(t.Addresses, t.RequiredForOnline), cmp.Or(getRouteMetric(t.LinkType, t.RouteMetric), 100), generateNetworkSectionContents(t.Name, networkCfg.VLANs, networkCfg.IPVLANs, networkCfg.DNS, nil, networkCfg.Time, nil))
 
-		cfgString += processAddresses(v.Addresses)
+		if t.KeepConfiguration != "" {
+			cfgString += "KeepConfiguration=" + t.KeepConfiguration + "\n"
+		}
 
-		if len(v.Routes) > 0 {
-			cfgString += processRoutes(v.Routes)
+		cfgString += processAddresses(t.Addresses, false, false, nil)
+		cfgString += generateDHCPv4Contents(false, "", nil, acceptsDefaultRoute(defaultRouteSource, t.Name), true)
+		cfgString += generateDHCPNTPContents(networkCfg.Time, t.Addresses)
+		cfgString += generateIPv6AcceptRAContents("", t.Addresses, acceptsDefaultRoute(defaultRouteSource, t.Name))
+
+		if len(t.Routes) > 0 {
+			cfgString += processRoutes(t.Routes, getRouteMetric(t.LinkType, t.RouteMetric))
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("22-%s.network", v.Name),
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-_v%s.network", NetworkFilePriority+teamFileOffset, t.Name),
 			Contents: cfgString,
 		})
-	}
 
-	// Create network for each Wireguard.
-	for _, wg := range networkCfg.Wireguard {
-		cfgString := fmt.Sprintf(`[Match]
-Name=%s
+		// Bridge side of veth device.
+		teamMacAddr := t.Hwaddr
+		if teamMacAddr == "" {
+			teamMacAddr = t.Members[0]
+		}
+
+		strippedHwaddr := strings.ToLower(strings.ReplaceAll(teamMacAddr, ":", ""))
+
+		cfgString = fmt.Sprintf(`[Match]
+Name=_i%s
 
 [Network]
-`, wg.Name)
+Bridge=%s
+`, strippedHwaddr, t.Name)
 
-		cfgString += processAddresses(wg.Addresses)
+		cfgString += generateVLANContents(t.Name, t.VLANTags, networkCfg.VLANs)
 
-		if len(wg.Routes) > 0 {
-			cfgString += processRoutes(wg.Routes)
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-_i%s.network", NetworkFilePriority+teamFileOffset, strippedHwaddr),
+			Contents: cfgString,
+		})
+
+		// Add team to bridge.
+		mtuString := ""
+		if t.MTU != 0 {
+			mtuString = fmt.Sprintf("\n[Link]\nMTUBytes=%d\n", t.MTU)
 		}
 
-		ret = append(ret, networkdConfigFile{
-			Name:     fmt.Sprintf("23-%s.network", wg.Name),
+		cfgString = fmt.Sprintf(`[Match]
+Name=_a%s
+%s
+[Network]
+LinkLocalAddressing=no
+ConfigureWithoutCarrier=yes
+Bridge=%s
+`, t.Name, mtuString, t.Name)
+
+		cfgString += generateVLANContents(t.Name, t.VLANTags, networkCfg.VLANs)
+
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-_a%s.network", NetworkFilePriority+teamFileOffset, t.Name),
 			Contents: cfgString,
 		})
-	}
 
-	return ret
-}
+		// Bridge.
+		cfgString = fmt.Sprintf(`[Match]
+Name=%s
 
-func processAddresses(addresses []string) string {
-	var ret strings.Builder
+[Network]
+LinkLocalAddressing=no
+ConfigureWithoutCarrier=yes
+`, t.Name)
 
-	if len(addresses) != 0 {
-		_, _ = ret.WriteString("LinkLocalAddressing=ipv6\n")
-	} else {
-		_, _ = ret.WriteString("LinkLocalAddressing=no\n")
-		_, _ = ret.WriteString("ConfigureWithoutCarrier=yes\n")
-	}
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.network", NetworkFilePriority+teamFileOffset, t.Name),
+			Contents: cfgString,
+		})
 
-	hasDHCP4 := false
-	hasDHCP6 := false
-	acceptIPv6RA := false
+		// Team members are driven by teamd, their files named after their MAC like bond members.
+		for _, member := range t.Members {
+			memberStrippedHwaddr := strings.ToLower(strings.ReplaceAll(member, ":", ""))
 
-	for _, addr := range addresses {
-		switch addr {
-		case "dhcp4":
-			hasDHCP4 = true
-		case "dhcp6":
-			acceptIPv6RA = true
-			hasDHCP6 = true
-		case "slaac":
-			acceptIPv6RA = true
+			ret = append(ret, NetworkdConfigFile{
+				Name: fmt.Sprintf("%02d-_a%s-_p%s.network", NetworkFilePriority+teamFileOffset, t.Name, memberStrippedHwaddr),
+				Contents: fmt.Sprintf(`[Match]
+Name=_p%s
 
-		default:
-			_, _ = fmt.Fprintf(&ret, "Address=%s\n", addr)
+[Link]
+Unmanaged=yes
+`, memberStrippedHwaddr),
+			})
 		}
 	}
 
-	if acceptIPv6RA {
-		_, _ = ret.WriteString("IPv6AcceptRA=true\n")
-	} else {
-		_, _ = ret.WriteString("IPv6AcceptRA=false\n")
-	}
+	// Create network for each VLAN.
+	for _, v := range networkCfg.VLANs {
+		cfgString := fmt.Sprintf(`[Match]
+Name=%s
 
-	if hasDHCP4 && hasDHCP6 { //nolint:gocritic
-		_, _ = ret.WriteString("DHCP=yes\n")
-	} else if hasDHCP4 {
-		_, _ = ret.WriteString("DHCP=ipv4\n")
-	} else if hasDHCP6 {
-		_, _ = ret.WriteString("DHCP=ipv6\n")
-	}
+[Link]
+%s
 
-	return ret.String()
-}
+[DHCP]
+ClientIdentifier=mac
+RouteMetric=%d
+UseMTU=true
 
-func processRoutes(routes []api.SystemNetworkRoute) string {
-	var ret strings.Builder
+[DHCPv6]
+WithoutRA=solicit
 
-	for _, route := range routes {
-		_, _ = ret.WriteString("\n[Route]\n")
+[Network]
+%s`, v.Name, generateLinkSectionContents(v.Addresses, v.RequiredForOnline), cmp.Or(getRouteMetric(v.LinkType, v.RouteMetric), 100), generateNetworkSectionContents(v.Name, nil, nil, networkCfg.DNS, v.DNS, networkCfg.Time, v.NTPServers))
 
-		switch route.Via {
-		case "dhcp4":
-			_, _ = ret.WriteString("Gateway=_dhcp4\n")
-		case "slaac":
-			_, _ = ret.WriteString("Gateway=_ipv6ra\n")
-		default:
-			_, _ = fmt.Fprintf(&ret, "Gateway=%s\n", route.Via)
+		if v.VRF != "" {
+			cfgString += "VRF=" + v.VRF + "\n"
 		}
 
-		_, _ = fmt.Fprintf(&ret, "Destination=%s\n", route.To)
-	}
+		if v.IPMasquerade != "" {
+			cfgString += "IPMasquerade=" + v.IPMasquerade + "\n"
+		}
 
-	return ret.String()
-}
+		if v.KeepConfiguration != "" {
+			cfgString += "KeepConfiguration=" + v.KeepConfiguration + "\n"
+		}
 
-func generateNetworkSectionContents(name string, vlans []api.SystemNetworkVLAN, dns *api.SystemNetworkDNS, timeCfg *api.SystemNetworkTime) string {
-	var ret strings.Builder
+		cfgString += generateIPForwardingContents(v.IPForwarding)
+		cfgString += processProxyARPNDP(v.IPv4ProxyARP, v.IPv6ProxyNDP, v.IPv6ProxyNDPAddresses)
 
-	// Add any matching VLANs to the config.
+		if v.IPv6DAD != nil {
+			cfgString += fmt.Sprintf("IPv6DuplicateAddressDetection=%d\n", *v.IPv6DAD)
+		}
 
-	for _, v := range vlans {
-		if v.Parent == name {
-			_, _ = fmt.Fprintf(&ret, "VLAN=%s\n", v.Name)
+		if v.IPv6AddressGenerationMode != "" {
+			cfgString += "IPv6LinkLocalAddressGenerationMode=" + v.IPv6AddressGenerationMode + "\n"
 		}
-	}
 
-	// If there are search domains or name servers or DNS over TLS defined, add those to the config.
+		cfgString += generatePrefixDelegationNetworkContents(v.PrefixDelegation)
+		cfgString += processAddresses(getPrefixDelegationAddresses(v.PrefixDelegation, v.Addresses), v.IPv4LinkLocal, v.DisableIPv6, v.AddressOptions)
+
+		cfgString += generateDHCPv4Contents(v.IPv6OnlyMode, v.DHCPVendorClass, v.DHCPUserClass, acceptsDefaultRoute(defaultRouteSource, v.Name), v.DHCPUseRoutes == nil || *v.DHCPUseRoutes)
+		cfgString += generateDHCPFallbackContents(v.DHCPFallbackAddress)
+		cfgString += generateDHCPNTPContents(networkCfg.Time, v.Addresses)
+		cfgString += generateIPv6AcceptRAContents(v.IPv6AddressGenerationMode, v.Addresses, acceptsDefaultRoute(defaultRouteSource, v.Name))
+
+		if len(v.Routes) > 0 {
+			cfgString += processRoutes(v.Routes, getRouteMetric(v.LinkType, v.RouteMetric))
+		}
+
+		cfgString += generateNeighborContents(v.Neighbors)
+		cfgString += generatePrefixDelegationContents(v.PrefixDelegation, pdUplink)
+		cfgString += generateQoSContents(v.QoS)
+		cfgString += generatePromiscuousContents(v.Promiscuous, v.AllMulticast)
+		cfgString += generateExtraOptionsContents(v.ExtraOptions)
+
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.network", NetworkFilePriority+vlanFileOffset+v.Priority, v.Name),
+			Contents: cfgString,
+		})
+	}
+
+	// Create network for each Wireguard.
+	for _, wg := range networkCfg.Wireguard {
+		cfgString := fmt.Sprintf(`[Match]
+Name=%s
+
+[Network]
+`, wg.Name)
+
+		cfgString += processAddresses(wg.Addresses, false, false, nil)
+
+		if len(wg.Routes) > 0 {
+			cfgString += processRoutes(wg.Routes, 0)
+		}
+
+		cfgString += generateExtraOptionsContents(wg.ExtraOptions)
+
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.network", NetworkFilePriority+wireguardFileOffset, wg.Name),
+			Contents: cfgString,
+		})
+	}
+
+	// Create network for each tunnel, and the bridge and veth devices of GRETAP tunnels.
+	for _, t := range networkCfg.Tunnels {
+		name := t.Name
+		if t.Kind == "gretap" {
+			name = "_v" + t.Name
+		}
+
+		cfgString := fmt.Sprintf(`[Match]
+Name=%s
+
+[Link]
+%s
+
+[Network]
+`, name, generateLinkSectionContents(t.Addresses, t.RequiredForOnline))
+
+		cfgString += processAddresses(t.Addresses, false, false, nil)
+
+		if len(t.Routes) > 0 {
+			cfgString += processRoutes(t.Routes, 0)
+		}
+
+		cfgString += generateExtraOptionsContents(t.ExtraOptions)
+
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.network", NetworkFilePriority+tunnelFileOffset, name),
+			Contents: cfgString,
+		})
+
+		if t.Kind != "gretap" {
+			continue
+		}
+
+		// Bridge side of veth device.
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_i%s.network", NetworkFilePriority+tunnelFileOffset, t.Name),
+			Contents: fmt.Sprintf(`[Match]
+Name=_i%s
+
+[Network]
+Bridge=%s
+`, t.Name, t.Name),
+		})
+
+		// Add tunnel to bridge.
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-_t%s.network", NetworkFilePriority+tunnelFileOffset, t.Name),
+			Contents: fmt.Sprintf(`[Match]
+Name=_t%s
+
+[Network]
+LinkLocalAddressing=no
+ConfigureWithoutCarrier=yes
+Bridge=%s
+`, t.Name, t.Name),
+		})
+
+		// Bridge.
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.network", NetworkFilePriority+tunnelFileOffset, t.Name),
+			Contents: fmt.Sprintf(`[Match]
+Name=%s
+
+[Network]
+LinkLocalAddressing=no
+ConfigureWithoutCarrier=yes
+`, t.Name),
+		})
+	}
+
+	// Create network for each VRF.
+	for _, v := range networkCfg.VRFs {
+		ret = append(ret, NetworkdConfigFile{
+			Name: fmt.Sprintf("%02d-%s.network", NetworkFilePriority+vrfFileOffset, v.Name),
+			Contents: fmt.Sprintf(`[Match]
+Name=%s
+
+[Link]
+RequiredForOnline=no
+
+[Network]
+LinkLocalAddressing=no
+ConfigureWithoutCarrier=yes
+`, v.Name),
+		})
+	}
+
+	// Create network for each IPVLAN.
+	for _, v := range networkCfg.IPVLANs {
+		cfgString := fmt.Sprintf(`[Match]
+Name=%s
+
+[Link]
+%s
+
+[DHCP]
+ClientIdentifier=mac
+RouteMetric=%d
+UseMTU=true
+
+[DHCPv6]
+WithoutRA=solicit
+
+[Network]
+%s`, v.Name, generateLinkSectionContents(v.Addresses, v.RequiredForOnline), cmp.Or(getRouteMetric(v.LinkType, v.RouteMetric), 100), generateNetworkSectionContents(v.Name, nil, nil, networkCfg.DNS, nil, networkCfg.Time, nil))
+
+		if v.KeepConfiguration != "" {
+			cfgString += "KeepConfiguration=" + v.KeepConfiguration + "\n"
+		}
+
+		cfgString += processAddresses(v.Addresses, false, false, nil)
+		cfgString += generateDHCPv4Contents(false, "", nil, acceptsDefaultRoute(defaultRouteSource, v.Name), true)
+		cfgString += generateDHCPNTPContents(networkCfg.Time, v.Addresses)
+		cfgString += generateIPv6AcceptRAContents("", v.Addresses, acceptsDefaultRoute(defaultRouteSource, v.Name))
+
+		if len(v.Routes) > 0 {
+			cfgString += processRoutes(v.Routes, getRouteMetric(v.LinkType, v.RouteMetric))
+		}
+
+		cfgString += generateExtraOptionsContents(v.ExtraOptions)
+
+		ret = append(ret, NetworkdConfigFile{
+			Name:     fmt.Sprintf("%02d-%s.network", NetworkFilePriority+ipvlanFileOffset, v.Name),
+			Contents: cfgString,
+		})
+	}
+
+	return ret
+}
+
+func processAddresses(addresses []string, ipv4LinkLocal bool, disableIPv6 bool, options []api.SystemNetworkAddressOptions) string {
+	var ret strings.Builder
+
+	switch {
+	case len(addresses) != 0 && disableIPv6 && ipv4LinkLocal:
+		_, _ = ret.WriteString("LinkLocalAddressing=ipv4\n")
+	case len(addresses) != 0 && disableIPv6:
+		_, _ = ret.WriteString("LinkLocalAddressing=no\n")
+	case len(addresses) != 0 && ipv4LinkLocal:
+		_, _ = ret.WriteString("LinkLocalAddressing=yes\n")
+	case len(addresses) != 0:
+		_, _ = ret.WriteString("LinkLocalAddressing=ipv6\n")
+	case ipv4LinkLocal:
+		_, _ = ret.WriteString("LinkLocalAddressing=ipv4\n")
+		_, _ = ret.WriteString("ConfigureWithoutCarrier=yes\n")
+	default:
+		_, _ = ret.WriteString("LinkLocalAddressing=no\n")
+		_, _ = ret.WriteString("ConfigureWithoutCarrier=yes\n")
+	}
+
+	hasDHCP4 := false
+	hasDHCP6 := false
+	acceptIPv6RA := false
+
+	for _, addr := range addresses {
+		switch addr {
+		case "dhcp4":
+			hasDHCP4 = true
+		case "dhcp6":
+			acceptIPv6RA = true
+			hasDHCP6 = true
+		case "slaac":
+			acceptIPv6RA = true
+		case "link-local":
+			// Only the IPv6 link-local address, enabled above, is configured.
+			continue
+
+		default:
+			// Addresses with options get their own [Address] section.
+			if slices.ContainsFunc(options, func(o api.SystemNetworkAddressOptions) bool { return o.Address == addr }) {
+				continue
+			}
+
+			_, _ = fmt.Fprintf(&ret, "Address=%s\n", addr)
+		}
+	}
+
+	if acceptIPv6RA {
+		_, _ = ret.WriteString("IPv6AcceptRA=true\n")
+	} else {
+		_, _ = ret.WriteString("IPv6AcceptRA=false\n")
+	}
+
+	if hasDHCP4 && hasDHCP6 { //nolint:gocritic
+		_, _ = ret.WriteString("DHCP=yes\n")
+	} else if hasDHCP4 {
+		_, _ = ret.WriteString("DHCP=ipv4\n")
+	} else if hasDHCP6 {
+		_, _ = ret.WriteString("DHCP=ipv6\n")
+	}
+
+	for _, o := range options {
+		_, _ = fmt.Fprintf(&ret, "\n[Address]\nAddress=%s\n", o.Address)
+
+		if o.Label != "" {
+			_, _ = fmt.Fprintf(&ret, "Label=%s\n", o.Label)
+		}
+
+		if o.Scope != "" {
+			_, _ = fmt.Fprintf(&ret, "Scope=%s\n", o.Scope)
+		}
+
+		if o.PreferredLifetime != "" {
+			_, _ = fmt.Fprintf(&ret, "PreferredLifetime=%s\n", o.PreferredLifetime)
+		}
+
+		if o.HomeAddress {
+			_, _ = ret.WriteString("HomeAddress=yes\n")
+		}
+	}
+
+	return ret.String()
+}
+
+func generateDHCPv4Contents(ipv6OnlyMode bool, vendorClass string, userClass []string, useGateway bool, useRoutes bool) string {
+	if !ipv6OnlyMode && vendorClass == "" && len(userClass) == 0 && useGateway && useRoutes {
+		return ""
+	}
+
+	var ret strings.Builder
+
+	_, _ = ret.WriteString("\n[DHCPv4]\n")
+
+	if ipv6OnlyMode {
+		_, _ = ret.WriteString("IPv6OnlyMode=yes\n")
+	}
+
+	if vendorClass != "" {
+		_, _ = fmt.Fprintf(&ret, "VendorClassIdentifier=%s\n", vendorClass)
+	}
+
+	if len(userClass) > 0 {
+		_, _ = fmt.Fprintf(&ret, "UserClass=%s\n", strings.Join(userClass, " "))
+	}
+
+	// UseGateway defaults to the value of UseRoutes, so must be explicitly kept when only routes are ignored.
+	if !useGateway {
+		_, _ = ret.WriteString("UseGateway=false\n")
+	} else if !useRoutes {
+		_, _ = ret.WriteString("UseGateway=true\n")
+	}
+
+	if !useRoutes {
+		_, _ = ret.WriteString("UseRoutes=false\n")
+	}
+
+	return ret.String()
+}
+
+// generateDHCPNTPContents stops the DHCP clients of the device from using the NTP servers they're offered.
+// The DHCPv6 client may also be started by router advertisements when using SLAAC.
+func generateDHCPNTPContents(timeCfg *api.SystemNetworkTime, addresses []string) string {
+	if timeCfg == nil || !timeCfg.IgnoreDHCP {
+		return ""
+	}
+
+	var ret strings.Builder
+
+	if slices.Contains(addresses, "dhcp4") {
+		_, _ = ret.WriteString("\n[DHCPv4]\nUseNTP=false\n")
+	}
+
+	if slices.Contains(addresses, "dhcp6") || slices.Contains(addresses, "slaac") {
+		_, _ = ret.WriteString("\n[DHCPv6]\nUseNTP=false\n")
+	}
+
+	return ret.String()
+}
+
+// generateDHCPFallbackContents generates the [Address] section of the static address assigned alongside DHCP.
+// IPv4 routes received through DHCP prefer the leased address as their source, while the kernel only skips
+// deprecated addresses for IPv6, so only IPv6 fallback addresses are marked as such.
+func generateDHCPFallbackContents(address string) string {
+	if address == "" {
+		return ""
+	}
+
+	ip, _, _ := net.ParseCIDR(address)
+	if ip.To4() != nil {
+		return fmt.Sprintf("\n[Address]\nAddress=%s\n", address)
+	}
+
+	return fmt.Sprintf("\n[Address]\nAddress=%s\nPreferredLifetime=0\n", address)
+}
+
+// generateIPv6AcceptRAContents generates the [IPv6AcceptRA] section of devices accepting router advertisements.
+// SLAAC addresses match the link-local address generation mode, "stable-privacy" and "random" both using RFC 7217.
+func generateIPv6AcceptRAContents(mode string, addresses []string, useGateway bool) string {
+	if !slices.Contains(addresses, "slaac") && !slices.Contains(addresses, "dhcp6") {
+		return ""
+	}
+
+	var ret strings.Builder
+
+	if slices.Contains(addresses, "slaac") {
+		switch mode {
+		case "eui64":
+			_, _ = ret.WriteString("Token=eui64\n")
+		case "stable-privacy", "random":
+			_, _ = ret.WriteString("Token=prefixstable\n")
+		}
+	}
+
+	if !useGateway {
+		_, _ = ret.WriteString("UseGateway=false\n")
+	}
+
+	if ret.Len() == 0 {
+		return ""
+	}
+
+	return "\n[IPv6AcceptRA]\n" + ret.String()
+}
+
+// acceptsDefaultRoute returns whether a device may use the gateway learned through DHCP or router
+// advertisements, which is restricted to the default route source when one is set.
+func acceptsDefaultRoute(source string, name string) bool {
+	return source == "" || source == name
+}
+
+// generateNeighborContents generates the [Neighbor] sections of permanent static neighbors. Reachable
+// ones are instead seeded by applyReachableNeighbors, as networkd always makes its neighbors permanent.
+func generateNeighborContents(neighbors []api.SystemNetworkNeighbor) string {
+	var ret strings.Builder
+
+	for _, n := range neighbors {
+		if !n.Permanent {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&ret, "\n[Neighbor]\nAddress=%s\nLinkLayerAddress=%s\n", n.Address, n.Hwaddr)
+	}
+
+	return ret.String()
+}
+
+// applyReachableNeighbors adds the non-permanent static neighbors of each device, which the kernel
+// then manages through its usual reachability state machine.
+func applyReachableNeighbors(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	apply := func(dev string, neighbors []api.SystemNetworkNeighbor) error {
+		for _, n := range neighbors {
+			if n.Permanent {
+				continue
+			}
+
+			_, err := subprocess.RunCommandContext(ctx, "ip", "neigh", "replace", n.Address, "lladdr", n.Hwaddr, "dev", dev, "nud", "reachable")
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, i := range networkCfg.Interfaces {
+		err := apply("_v"+i.Name, i.Neighbors)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, b := range networkCfg.Bonds {
+		err := apply("_v"+b.Name, b.Neighbors)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, v := range networkCfg.VLANs {
+		err := apply(v.Name, v.Neighbors)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyHardwareTimestamping enables transmit and receive hardware timestamping on the physical device of
+// each interface requesting it, logging the mode accepted by the driver, which may differ from the one requested.
+// Devices which had it enabled by the previous configuration are reset, a failure only being logged as the
+// device may be gone.
+func applyHardwareTimestamping(ctx context.Context, previousCfg *api.SystemNetworkConfig, networkCfg *api.SystemNetworkConfig) error {
+	for _, args := range getHardwareTimestampingCommands(previousCfg, networkCfg) {
+		dev := args[1]
+
+		output, err := subprocess.RunCommandContext(ctx, "hwstamp_ctl", args...)
+		if args[3] == "0" {
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to disable hardware timestamping", "device", dev, "err", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to enable hardware timestamping on '%s': %w", dev, err)
+		}
+
+		// Only keep the new settings, reported after the current ones.
+		_, accepted, _ := strings.Cut(output, "new settings:")
+
+		slog.InfoContext(ctx, "Enabled hardware timestamping", "device", dev, "settings", strings.Join(strings.Fields(accepted), " "))
+	}
+
+	return nil
+}
+
+// getHardwareTimestampingCommands returns the hwstamp_ctl arguments resetting the devices no longer requesting
+// hardware timestamping, followed by those enabling it on the devices requesting it.
+func getHardwareTimestampingCommands(previousCfg *api.SystemNetworkConfig, networkCfg *api.SystemNetworkConfig) [][]string {
+	// Receive filters, as defined by the kernel's HWTSTAMP_FILTER_* values.
+	rxFilters := map[string]string{
+		"all": "1",
+		"ptp": "12",
+	}
+
+	enabled := map[string]string{}
+	enabledDevices := []string{}
+
+	for _, i := range networkCfg.Interfaces {
+		if i.HardwareTimestamping != "" {
+			enabled[getInterfaceDevice(i)] = rxFilters[i.HardwareTimestamping]
+			enabledDevices = append(enabledDevices, getInterfaceDevice(i))
+		}
+	}
+
+	ret := [][]string{}
+
+	if previousCfg != nil {
+		for _, i := range previousCfg.Interfaces {
+			_, ok := enabled[getInterfaceDevice(i)]
+			if i.HardwareTimestamping != "" && !ok {
+				ret = append(ret, []string{"-i", getInterfaceDevice(i), "-t", "0", "-r", "0"})
+			}
+		}
+	}
+
+	for _, dev := range enabledDevices {
+		ret = append(ret, []string{"-i", dev, "-t", "1", "-r", enabled[dev]})
+	}
+
+	return ret
+}
+
+func processProxyARPNDP(proxyARP bool, proxyNDP bool, proxyNDPAddresses []string) string {
+	var ret strings.Builder
+
+	if proxyARP {
+		_, _ = ret.WriteString("IPv4ProxyARP=yes\n")
+	}
+
+	if proxyNDP {
+		_, _ = ret.WriteString("IPv6ProxyNDP=yes\n")
+	}
+
+	for _, addr := range proxyNDPAddresses {
+		_, _ = fmt.Fprintf(&ret, "IPv6ProxyNDPAddress=%s\n", addr)
+	}
+
+	return ret.String()
+}
+
+// getRouteMetric returns the route metric for a device, using the explicit override if set or
+// otherwise deriving it from the link type. Zero is returned when neither is configured.
+func getRouteMetric(linkType string, routeMetric int) int {
+	if routeMetric != 0 {
+		return routeMetric
+	}
+
+	switch linkType {
+	case "wired":
+		return 100
+	case "wireless":
+		return 600
+	case "lte":
+		return 700
+	default:
+		return 0
+	}
+}
+
+func processRoutes(routes []api.SystemNetworkRoute, metric int) string {
+	var ret strings.Builder
+
+	for _, route := range routes {
+		// Routes monitored by BFD are managed by FRR instead.
+		if route.BFD != nil {
+			continue
+		}
+
+		_, _ = ret.WriteString("\n[Route]\n")
+
+		switch {
+		case len(route.Gateways) > 0:
+			for _, gateway := range route.Gateways {
+				if gateway.Weight > 0 {
+					_, _ = fmt.Fprintf(&ret, "MultiPathRoute=%s %d\n", gateway.Address, gateway.Weight)
+				} else {
+					_, _ = fmt.Fprintf(&ret, "MultiPathRoute=%s\n", gateway.Address)
+				}
+			}
+		case route.Via == "dhcp4":
+			_, _ = ret.WriteString("Gateway=_dhcp4\n")
+		case route.Via == "slaac":
+			_, _ = ret.WriteString("Gateway=_ipv6ra\n")
+		default:
+			_, _ = fmt.Fprintf(&ret, "Gateway=%s\n", route.Via)
+		}
+
+		_, _ = fmt.Fprintf(&ret, "Destination=%s\n", route.To)
+
+		if route.Table != 0 {
+			_, _ = fmt.Fprintf(&ret, "Table=%d\n", route.Table)
+		}
+
+		if route.Source != "" {
+			_, _ = fmt.Fprintf(&ret, "PreferredSource=%s\n", route.Source)
+		}
+
+		if route.MTU != 0 {
+			_, _ = fmt.Fprintf(&ret, "MTUBytes=%d\n", route.MTU)
+		}
+
+		if metric != 0 {
+			_, _ = fmt.Fprintf(&ret, "Metric=%d\n", metric)
+		}
+	}
+
+	return ret.String()
+}
+
+// getDefaultRouteSource returns the name of the only device allowed to use a learned gateway, if any.
+func getDefaultRouteSource(networkCfg api.SystemNetworkConfig) string {
+	for _, i := range networkCfg.Interfaces {
+		if i.DefaultRouteSource {
+			return i.Name
+		}
+	}
+
+	for _, b := range networkCfg.Bonds {
+		if b.DefaultRouteSource {
+			return b.Name
+		}
+	}
+
+	for _, v := range networkCfg.VLANs {
+		if v.DefaultRouteSource {
+			return v.Name
+		}
+	}
+
+	for _, v := range networkCfg.IPVLANs {
+		if v.DefaultRouteSource {
+			return v.Name
+		}
+	}
+
+	return ""
+}
+
+// getPrefixDelegationUplink returns the name of the layer 3 device requesting a delegated prefix, if any.
+func getPrefixDelegationUplink(networkCfg api.SystemNetworkConfig) string {
+	for _, i := range networkCfg.Interfaces {
+		if i.PrefixDelegation != nil && i.PrefixDelegation.Mode == "uplink" {
+			return "_v" + i.Name
+		}
+	}
+
+	for _, b := range networkCfg.Bonds {
+		if b.PrefixDelegation != nil && b.PrefixDelegation.Mode == "uplink" {
+			return "_v" + b.Name
+		}
+	}
+
+	for _, v := range networkCfg.VLANs {
+		if v.PrefixDelegation != nil && v.PrefixDelegation.Mode == "uplink" {
+			return v.Name
+		}
+	}
+
+	return ""
+}
+
+// generatePrefixDelegationNetworkContents generates the [Network] settings of a prefix delegation downstream device.
+func generatePrefixDelegationNetworkContents(pd *api.SystemNetworkPrefixDelegation) string {
+	if pd == nil || pd.Mode != "downstream" {
+		return ""
+	}
+
+	return "DHCPPrefixDelegation=yes\nIPv6SendRA=yes\n"
+}
+
+// getPrefixDelegationAddresses returns the addresses of a device. Router advertisements require an IPv6
+// link-local address, so it is configured on prefix delegation downstream devices without any address.
+func getPrefixDelegationAddresses(pd *api.SystemNetworkPrefixDelegation, addresses []string) []string {
+	if pd == nil || pd.Mode != "downstream" || len(addresses) > 0 {
+		return addresses
+	}
+
+	return []string{"link-local"}
+}
+
+func generatePrefixDelegationContents(pd *api.SystemNetworkPrefixDelegation, uplink string) string {
+	if pd == nil {
+		return ""
+	}
+
+	var ret strings.Builder
+
+	if pd.Mode == "uplink" {
+		if pd.PrefixLength != 0 {
+			_, _ = fmt.Fprintf(&ret, "\n[DHCPv6]\nPrefixDelegationHint=::/%d\n", pd.PrefixLength)
+		}
+
+		return ret.String()
+	}
+
+	_, _ = fmt.Fprintf(&ret, "\n[DHCPPrefixDelegation]\nUplinkInterface=%s\n", uplink)
+
+	if pd.SubnetID != nil {
+		_, _ = fmt.Fprintf(&ret, "SubnetId=%d\n", *pd.SubnetID)
+	}
+
+	if pd.RouterPreference != "" || pd.HopLimit != 0 {
+		_, _ = ret.WriteString("\n[IPv6SendRA]\n")
+
+		if pd.RouterPreference != "" {
+			_, _ = fmt.Fprintf(&ret, "RouterPreference=%s\n", pd.RouterPreference)
+		}
+
+		if pd.HopLimit != 0 {
+			_, _ = fmt.Fprintf(&ret, "HopLimit=%d\n", pd.HopLimit)
+		}
+	}
+
+	for _, route := range pd.AdvertisedRoutes {
+		_, _ = fmt.Fprintf(&ret, "\n[IPv6RoutePrefix]\nRoute=%s\n", route.Route)
+
+		if route.Lifetime != "" {
+			lifetime, _ := time.ParseDuration(route.Lifetime)
+			_, _ = fmt.Fprintf(&ret, "LifetimeSec=%d\n", int(lifetime.Seconds()))
+		}
+	}
+
+	return ret.String()
+}
+
+// generateExtraOptionsContents appends the user provided options, ordered by section name.
+func generateExtraOptionsContents(extraOptions map[string][]string) string {
+	var ret strings.Builder
+
+	for _, section := range slices.Sorted(maps.Keys(extraOptions)) {
+		_, _ = fmt.Fprintf(&ret, "\n[%s]\n", section)
+
+		for _, option := range extraOptions[section] {
+			_, _ = ret.WriteString(option + "\n")
+		}
+	}
+
+	return ret.String()
+}
+
+func generateQoSContents(qos *api.SystemNetworkQoS) string {
+	if qos == nil {
+		return ""
+	}
+
+	var ret strings.Builder
+
+	if qos.Bandwidth != "" {
+		_, _ = fmt.Fprintf(&ret, "\n[CAKE]\nParent=root\nBandwidth=%s\n", qos.Bandwidth)
+	}
+
+	if len(qos.Classes) > 0 {
+		defaultClass := qos.DefaultClass
+		if defaultClass == 0 {
+			defaultClass = qos.Classes[0].ID
+		}
+
+		_, _ = fmt.Fprintf(&ret, "\n[HierarchyTokenBucket]\nParent=root\nHandle=0001\nDefaultClass=%x\n", defaultClass)
+
+		for _, class := range qos.Classes {
+			_, _ = fmt.Fprintf(&ret, "\n[HierarchyTokenBucketClass]\nParent=1:0\nClassId=1:%x\nPriority=%d\nRate=%s\n", class.ID, class.Priority, class.Rate)
+
+			if class.CeilRate != "" {
+				_, _ = fmt.Fprintf(&ret, "CeilRate=%s\n", class.CeilRate)
+			}
+		}
+	}
+
+	return ret.String()
+}
+
+// generateEgressRateLimitContents caps the traffic sent through a bridge port, using a token bucket
+// filter holding up to 10ms of traffic at the given rate.
+func generateEgressRateLimitContents(rate string) string {
+	if rate == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("\n[TokenBucketFilter]\nParent=root\nRate=%s\nBurstBytes=%d\nLatencySec=50ms\n", rate, getRateLimitBurst(rate))
+}
+
+// getRateLimitBurst returns the number of bytes sent in 10ms at the given rate, with a minimum of 16KiB.
+func getRateLimitBurst(rate string) uint64 {
+	return max(parseBandwidth(rate)/8/100, 16*1024)
+}
+
+// parseBandwidth converts a bandwidth in bits per second, with an optional K, M or G suffix (base 1000).
+func parseBandwidth(bandwidth string) uint64 {
+	multiplier := 1.0
+
+	switch {
+	case strings.HasSuffix(bandwidth, "K"):
+		multiplier = 1e3
+	case strings.HasSuffix(bandwidth, "M"):
+		multiplier = 1e6
+	case strings.HasSuffix(bandwidth, "G"):
+		multiplier = 1e9
+	}
+
+	value, _ := strconv.ParseFloat(strings.TrimRight(bandwidth, "KMG"), 64)
+
+	return uint64(value * multiplier)
+}
+
+// applyIngressRateLimits polices the traffic received by bridge ports with an ingress rate limit, dropping
+// anything over the limit, as systemd-networkd can't configure ingress filters. Stale policers are removed.
+func applyIngressRateLimits(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	apply := func(dev string, rate string) error {
+		// Start from a clean ingress qdisc, ignoring the error if there's none yet.
+		_, _ = subprocess.RunCommandContext(ctx, "tc", "qdisc", "del", "dev", dev, "ingress")
+
+		if rate == "" {
+			return nil
+		}
+
+		_, err := subprocess.RunCommandContext(ctx, "tc", "qdisc", "add", "dev", dev, "handle", "ffff:", "ingress")
+		if err != nil {
+			return err
+		}
+
+		_, err = subprocess.RunCommandContext(ctx, "tc", "filter", "add", "dev", dev, "parent", "ffff:", "protocol", "all", "prio", "1", "matchall",
+			"action", "police", "rate", strconv.FormatUint(parseBandwidth(rate), 10)+"bit", "burst", strconv.FormatUint(getRateLimitBurst(rate), 10), "conform-exceed", "drop")
+		if err != nil {
+			return fmt.Errorf("failed to set ingress rate limit on '%s': %w", dev, err)
+		}
+
+		return nil
+	}
+
+	for _, i := range networkCfg.Interfaces {
+		if i.Management {
+			continue
+		}
+
+		err := apply(getInterfaceDevice(i), i.IngressRateLimit)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, b := range networkCfg.Bonds {
+		err := apply("_b"+b.Name, b.IngressRateLimit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateNetworkSectionContents(name string, vlans []api.SystemNetworkVLAN, ipvlans []api.SystemNetworkIPVLAN, dns *api.SystemNetworkDNS, linkDNS *api.SystemNetworkLinkDNS, timeCfg *api.SystemNetworkTime, linkNTP []string) string {
+	var ret strings.Builder
+
+	// Add any matching VLANs to the config.
+
+	for _, v := range vlans {
+		if v.Parent == name {
+			_, _ = fmt.Fprintf(&ret, "VLAN=%s\n", v.Name)
+		}
+	}
+
+	// Add any matching IPVLANs to the config.
+	for _, v := range ipvlans {
+		if v.Parent == name {
+			_, _ = fmt.Fprintf(&ret, "IPVLAN=%s\n", v.Name)
+		}
+	}
+
+	// If there are search domains or name servers or DNS over TLS defined, add those to the config.
+	// A device specific DNS configuration replaces the global search domains and name servers.
+	// Routing-only domains are prefixed with "~" so resolved doesn't add them to the search path.
+	searchDomains := []string{}
+	routingDomains := []string{}
+	nameservers := []string{}
+
+	if linkDNS != nil {
+		searchDomains = linkDNS.SearchDomains
+		routingDomains = linkDNS.RoutingDomains
+		nameservers = linkDNS.Nameservers
+	} else if dns != nil {
+		searchDomains = dns.SearchDomains
+		routingDomains = dns.RoutingDomains
+		nameservers = dns.Nameservers
+	}
+
+	domains := slices.Clone(searchDomains)
+
+	for _, domain := range routingDomains {
+		domains = append(domains, "~"+domain)
+	}
+
+	if len(domains) > 0 {
+		_, _ = fmt.Fprintf(&ret, "Domains=%s\n", strings.Join(domains, " "))
+	}
+
+	for _, ns := range nameservers {
+		_, _ = fmt.Fprintf(&ret, "DNS=%s\n", ns)
+	}
+
 	if dns != nil {
-		if len(dns.SearchDomains) > 0 {
-			_, _ = fmt.Fprintf(&ret, "Domains=%s\n", strings.Join(dns.SearchDomains, " "))
+		if dns.DNSOverTLS {
+			_, _ = fmt.Fprint(&ret, "DNSOverTLS=yes\n")
+		}
+	}
+
+	// If there are time servers defined, add them to the config. Device specific time servers
+	// replace the global ones.
+	ntpServers := linkNTP
+	if len(ntpServers) == 0 && timeCfg != nil {
+		ntpServers = timeCfg.NTPServers
+	}
+
+	for _, ts := range ntpServers {
+		_, _ = fmt.Fprintf(&ret, "NTP=%s\n", ts)
+	}
+
+	return ret.String()
+}
+
+func generateTimesyncContents(timeCfg api.SystemNetworkTime) string {
+	if len(timeCfg.NTPServers) == 0 && timeCfg.PollIntervalMin == "" && timeCfg.PollIntervalMax == "" && timeCfg.RootDistanceMax == "" {
+		return ""
+	}
+
+	var ret strings.Builder
+
+	_, _ = ret.WriteString("[Time]\n")
+
+	if len(timeCfg.NTPServers) > 0 {
+		_, _ = ret.WriteString("FallbackNTP=" + strings.Join(timeCfg.NTPServers, " ") + "\n")
+	}
+
+	// Durations have already been validated, so emit them in units timesyncd can't misinterpret.
+	pollIntervalMin, err := time.ParseDuration(timeCfg.PollIntervalMin)
+	if err == nil {
+		_, _ = fmt.Fprintf(&ret, "PollIntervalMinSec=%ds\n", int(pollIntervalMin.Seconds()))
+	}
+
+	pollIntervalMax, err := time.ParseDuration(timeCfg.PollIntervalMax)
+	if err == nil {
+		_, _ = fmt.Fprintf(&ret, "PollIntervalMaxSec=%ds\n", int(pollIntervalMax.Seconds()))
+	}
+
+	rootDistanceMax, err := time.ParseDuration(timeCfg.RootDistanceMax)
+	if err == nil {
+		_, _ = fmt.Fprintf(&ret, "RootDistanceMaxSec=%dms\n", rootDistanceMax.Milliseconds())
+	}
+
+	return ret.String()
+}
+
+// generateBridgeContents returns any additional [Bridge] options for a device's bridge.
+func generateBridgeContents(defaultPVID *int, vlanProtocol string, stp bool) string {
+	var ret strings.Builder
+
+	if stp {
+		_, _ = ret.WriteString("STP=true\n")
+	}
+
+	if defaultPVID != nil {
+		if *defaultPVID == 0 {
+			_, _ = ret.WriteString("DefaultPVID=none\n")
+		} else {
+			_, _ = fmt.Fprintf(&ret, "DefaultPVID=%d\n", *defaultPVID)
 		}
+	}
+
+	if vlanProtocol != "" {
+		_, _ = fmt.Fprintf(&ret, "VLANProtocol=%s\n", vlanProtocol)
+	}
+
+	return ret.String()
+}
+
+// generateBridgePortContents returns the STP path cost and priority of a bridge port, if set.
+func generateBridgePortContents(cost int, priority *int) string {
+	if cost == 0 && priority == nil {
+		return ""
+	}
 
-		for _, ns := range dns.Nameservers {
-			_, _ = fmt.Fprintf(&ret, "DNS=%s\n", ns)
-		}
+	var ret strings.Builder
 
-		if dns.DNSOverTLS {
-			_, _ = fmt.Fprint(&ret, "DNSOverTLS=yes\n")
-		}
+	_, _ = ret.WriteString("\n[Bridge]\n")
+
+	if cost != 0 {
+		_, _ = fmt.Fprintf(&ret, "Cost=%d\n", cost)
 	}
 
-	// If there are time servers defined, add them to the config.
-	if timeCfg != nil {
-		for _, ts := range timeCfg.NTPServers {
-			_, _ = fmt.Fprintf(&ret, "NTP=%s\n", ts)
-		}
+	if priority != nil {
+		_, _ = fmt.Fprintf(&ret, "Priority=%d\n", *priority)
 	}
 
 	return ret.String()
 }
 
-func generateTimesyncContents(timeCfg api.SystemNetworkTime) string {
-	if len(timeCfg.NTPServers) == 0 {
+// generatePromiscuousContents enables promiscuous and/or all-multicast mode on the device.
+func generatePromiscuousContents(promiscuous bool, allMulticast bool) string {
+	if !promiscuous && !allMulticast {
 		return ""
 	}
 
-	return "[Time]\nFallbackNTP=" + strings.Join(timeCfg.NTPServers, " ") + "\n"
+	var ret strings.Builder
+
+	_, _ = ret.WriteString("\n[Link]\n")
+
+	if promiscuous {
+		_, _ = ret.WriteString("Promiscuous=yes\n")
+	}
+
+	if allMulticast {
+		_, _ = ret.WriteString("AllMulticast=yes\n")
+	}
+
+	return ret.String()
 }
 
 func generateVLANContents(devName string, additionalVLANTags []int, vlans []api.SystemNetworkVLAN) string {
@@ -1916,19 +4469,111 @@ func generateVLANContents(devName string, additionalVLANTags []int, vlans []api.
 	return ret.String()
 }
 
+// generateIPForwardingContents returns the [Network] options enabling forwarding for the requested address families.
+func generateIPForwardingContents(ipForwarding string) string {
+	ret := ""
+
+	if ipForwarding == "ipv4" || ipForwarding == "both" {
+		ret += "IPv4Forwarding=yes\n"
+	}
+
+	if ipForwarding == "ipv6" || ipForwarding == "both" {
+		ret += "IPv6Forwarding=yes\n"
+	}
+
+	return ret
+}
+
+// generateBridgeFDBContents returns the static bridge forwarding entries pointing at the given destination port.
+func generateBridgeFDBContents(entries []api.SystemNetworkBridgeFDB, destination string) string {
+	var ret strings.Builder
+
+	for _, entry := range entries {
+		if cmp.Or(entry.Destination, "uplink") != destination {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&ret, "\n[BridgeFDB]\nMACAddress=%s\nAssociatedWith=master\n", entry.Hwaddr)
+
+		if entry.VLAN != 0 {
+			_, _ = fmt.Fprintf(&ret, "VLANId=%d\n", entry.VLAN)
+		}
+	}
+
+	return ret.String()
+}
+
+// generateCarrierDelayContents returns the options allowing a physical device to go without carrier for
+// the given duration, such as while a slow SFP+ module is training, before networkd reacts to it.
+func generateCarrierDelayContents(carrierDelay string) string {
+	if carrierDelay == "" {
+		return ""
+	}
+
+	delay, err := time.ParseDuration(carrierDelay)
+	if err != nil {
+		return ""
+	}
+
+	if delay%time.Second == 0 {
+		return fmt.Sprintf("IgnoreCarrierLoss=%ds\n", int(delay.Seconds()))
+	}
+
+	return fmt.Sprintf("IgnoreCarrierLoss=%dms\n", delay.Milliseconds())
+}
+
 func generateLinkSectionContents(addresses []string, requiredForOnline string) string {
-	if len(addresses) == 0 || requiredForOnline == "no" {
+	// Link-local only devices never get a routable address.
+	if len(addresses) == 0 || requiredForOnline == "no" || slices.Contains(addresses, "link-local") {
 		return "RequiredForOnline=no"
 	}
 
-	if requiredForOnline == "" {
-		requiredForOnline = "any"
+	return "RequiredForOnline=yes\nRequiredFamilyForOnline=" + requiredFamilyForOnline(requiredForOnline)
+}
+
+// requiredFamilyForOnline maps a RequiredForOnline value to the corresponding systemd-networkd address family.
+func requiredFamilyForOnline(requiredForOnline string) string {
+	switch requiredForOnline {
+	case "":
+		return "any"
+	case "ipv4+ipv6":
+		return "both"
+	default:
+		return requiredForOnline
+	}
+}
+
+// hasAddressForFamily checks if the addresses satisfy the required address family.
+func hasAddressForFamily(addresses []string, family string) bool {
+	hasIPv4 := false
+	hasIPv6 := false
+
+	for _, address := range addresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			continue
+		}
+
+		if ip.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
 	}
 
-	return "RequiredForOnline=yes\nRequiredFamilyForOnline=" + requiredForOnline
+	switch family {
+	case "ipv4":
+		return hasIPv4
+	case "ipv6":
+		return hasIPv6
+	case "both":
+		return hasIPv4 && hasIPv6
+	default:
+		return hasIPv4 || hasIPv6
+	}
 }
 
-func cleanupStaleDevices(ctx context.Context, oldCfg *api.SystemNetworkConfig, newCfg *api.SystemNetworkConfig) error {
+func cleanupStaleDevices(ctx context.Context, oldCfg *api.SystemNetworkConfig, newCfg *api.SystemNetworkConfig) (bool, error) {
 	deleteInterfaces := []string{}
 
 	// Check for changed/deleted interfaces.
@@ -1947,12 +4592,12 @@ func cleanupStaleDevices(ctx context.Context, oldCfg *api.SystemNetworkConfig, n
 		// Check if the interface's configuration has changed.
 		oldConfig, err := json.Marshal(oldCfg.Interfaces[oldIndex])
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		newConfig, err := json.Marshal(newCfg.Interfaces[newIndex])
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		if !bytes.Equal(oldConfig, newConfig) {
@@ -1982,12 +4627,12 @@ func cleanupStaleDevices(ctx context.Context, oldCfg *api.SystemNetworkConfig, n
 		// Check if the bond's configuration has changed.
 		oldConfig, err := json.Marshal(oldCfg.Bonds[oldIndex])
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		newConfig, err := json.Marshal(newCfg.Bonds[newIndex])
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		if !bytes.Equal(oldConfig, newConfig) {
@@ -2001,6 +4646,39 @@ func cleanupStaleDevices(ctx context.Context, oldCfg *api.SystemNetworkConfig, n
 		}
 	}
 
+	// Check for changed/deleted teams. The team device itself is removed by teamd.
+	for oldIndex := range oldCfg.Teams {
+		newIndex := slices.IndexFunc(newCfg.Teams, func(t api.SystemNetworkTeam) bool {
+			return oldCfg.Teams[oldIndex].Name == t.Name
+		})
+
+		if newIndex < 0 {
+			deleteInterfaces = append(deleteInterfaces, "_v"+oldCfg.Teams[oldIndex].Name, oldCfg.Teams[oldIndex].Name)
+
+			continue
+		}
+
+		oldConfig, err := json.Marshal(oldCfg.Teams[oldIndex])
+		if err != nil {
+			return false, err
+		}
+
+		newConfig, err := json.Marshal(newCfg.Teams[newIndex])
+		if err != nil {
+			return false, err
+		}
+
+		if !bytes.Equal(oldConfig, newConfig) {
+			deleteInterfaces = append(deleteInterfaces, "_v"+oldCfg.Teams[oldIndex].Name)
+
+			if !isBridgeInUse(oldCfg.Teams[oldIndex].Name) {
+				deleteInterfaces = append(deleteInterfaces, oldCfg.Teams[oldIndex].Name)
+			}
+
+			continue
+		}
+	}
+
 	// Check for changed/deleted vlans.
 	for oldIndex := range oldCfg.VLANs {
 		newIndex := slices.IndexFunc(newCfg.VLANs, func(v api.SystemNetworkVLAN) bool {
@@ -2017,12 +4695,12 @@ func cleanupStaleDevices(ctx context.Context, oldCfg *api.SystemNetworkConfig, n
 		// Check if the vlan's configuration has changed.
 		oldConfig, err := json.Marshal(oldCfg.VLANs[oldIndex])
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		newConfig, err := json.Marshal(newCfg.VLANs[newIndex])
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		if !bytes.Equal(oldConfig, newConfig) {
@@ -2048,12 +4726,12 @@ func cleanupStaleDevices(ctx context.Context, oldCfg *api.SystemNetworkConfig, n
 		// Check if the wireguard configuration has changed.
 		oldConfig, err := json.Marshal(oldCfg.Wireguard[oldIndex]) // #nosec G117
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		newConfig, err := json.Marshal(newCfg.Wireguard[newIndex]) // #nosec G117
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		if !bytes.Equal(oldConfig, newConfig) {
@@ -2063,12 +4741,90 @@ func cleanupStaleDevices(ctx context.Context, oldCfg *api.SystemNetworkConfig, n
 		}
 	}
 
+	// Check for changed/deleted tunnels.
+	for oldIndex := range oldCfg.Tunnels {
+		newIndex := slices.IndexFunc(newCfg.Tunnels, func(t api.SystemNetworkTunnel) bool {
+			return oldCfg.Tunnels[oldIndex].Name == t.Name
+		})
+
+		// If not found, remove the existing tunnel.
+		if newIndex < 0 {
+			deleteInterfaces = append(deleteInterfaces, "_t"+oldCfg.Tunnels[oldIndex].Name, "_v"+oldCfg.Tunnels[oldIndex].Name, oldCfg.Tunnels[oldIndex].Name)
+
+			continue
+		}
+
+		// Check if the tunnel's configuration has changed.
+		oldConfig, err := json.Marshal(oldCfg.Tunnels[oldIndex])
+		if err != nil {
+			return false, err
+		}
+
+		newConfig, err := json.Marshal(newCfg.Tunnels[newIndex])
+		if err != nil {
+			return false, err
+		}
+
+		if !bytes.Equal(oldConfig, newConfig) {
+			deleteInterfaces = append(deleteInterfaces, "_t"+oldCfg.Tunnels[oldIndex].Name, "_v"+oldCfg.Tunnels[oldIndex].Name)
+
+			if oldCfg.Tunnels[oldIndex].Kind != "gretap" || !isBridgeInUse(oldCfg.Tunnels[oldIndex].Name) {
+				deleteInterfaces = append(deleteInterfaces, oldCfg.Tunnels[oldIndex].Name)
+			}
+
+			continue
+		}
+	}
+
+	// Check for changed/deleted IPVLANs.
+	for oldIndex := range oldCfg.IPVLANs {
+		newIndex := slices.IndexFunc(newCfg.IPVLANs, func(v api.SystemNetworkIPVLAN) bool {
+			return oldCfg.IPVLANs[oldIndex].Name == v.Name
+		})
+
+		// If not found, remove the existing IPVLAN.
+		if newIndex < 0 {
+			deleteInterfaces = append(deleteInterfaces, oldCfg.IPVLANs[oldIndex].Name)
+
+			continue
+		}
+
+		// Check if the IPVLAN's configuration has changed.
+		oldConfig, err := json.Marshal(oldCfg.IPVLANs[oldIndex])
+		if err != nil {
+			return false, err
+		}
+
+		newConfig, err := json.Marshal(newCfg.IPVLANs[newIndex])
+		if err != nil {
+			return false, err
+		}
+
+		if !bytes.Equal(oldConfig, newConfig) {
+			deleteInterfaces = append(deleteInterfaces, oldCfg.IPVLANs[oldIndex].Name)
+
+			continue
+		}
+	}
+
+	// Check for changed/deleted VRFs.
+	for oldIndex := range oldCfg.VRFs {
+		newIndex := slices.IndexFunc(newCfg.VRFs, func(v api.SystemNetworkVRF) bool {
+			return oldCfg.VRFs[oldIndex].Name == v.Name
+		})
+
+		// If not found or the routing table has changed, remove the existing VRF.
+		if newIndex < 0 || oldCfg.VRFs[oldIndex].Table != newCfg.VRFs[newIndex].Table {
+			deleteInterfaces = append(deleteInterfaces, oldCfg.VRFs[oldIndex].Name)
+		}
+	}
+
 	// Delete all the interfaces.
 	if len(deleteInterfaces) > 0 {
 		deleteNetworkDevice(ctx, deleteInterfaces...)
 	}
 
-	return nil
+	return len(deleteInterfaces) > 0, nil
 }
 
 // isBridgeInUse checks if a bridge exists and only contains internal ports.
@@ -2148,6 +4904,28 @@ func resolveMACs(ctx context.Context, config *api.SystemNetworkConfig) error {
 		}
 	}
 
+	for i := range len(config.Teams) {
+		if config.Teams[i].Hwaddr != "" && !hwaddrhRegex.MatchString(config.Teams[i].Hwaddr) {
+			hwaddr, err := getMacForInterface(ctx, config.Teams[i].Hwaddr)
+			if err != nil {
+				return fmt.Errorf("team %d failed getting MAC for '%s': %s", i, config.Teams[i].Hwaddr, err.Error())
+			}
+
+			config.Teams[i].Hwaddr = hwaddr
+		}
+
+		for j := range len(config.Teams[i].Members) {
+			if !hwaddrhRegex.MatchString(config.Teams[i].Members[j]) {
+				hwaddr, err := getMacForInterface(ctx, config.Teams[i].Members[j])
+				if err != nil {
+					return fmt.Errorf("team %d member %d failed getting MAC for '%s': %s", i, j, config.Teams[i].Members[j], err.Error())
+				}
+
+				config.Teams[i].Members[j] = hwaddr
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -2177,7 +4955,7 @@ func getExpectedNewPhysicalDevices(ctx context.Context, config *api.SystemNetwor
 	for i := range config.Interfaces {
 		devices = append(devices, expPhysDev{
 			Name:      config.Interfaces[i].Name,
-			Interface: "_p" + strings.ToLower(strings.ReplaceAll(config.Interfaces[i].Hwaddr, ":", "")),
+			Interface: getInterfaceDevice(config.Interfaces[i]),
 			Hwaddr:    strings.ToLower(config.Interfaces[i].Hwaddr),
 		})
 	}
@@ -2192,6 +4970,16 @@ func getExpectedNewPhysicalDevices(ctx context.Context, config *api.SystemNetwor
 		}
 	}
 
+	for i := range config.Teams {
+		for j := range config.Teams[i].Members {
+			devices = append(devices, expPhysDev{
+				Name:      config.Teams[i].Name,
+				Interface: "_p" + strings.ToLower(strings.ReplaceAll(config.Teams[i].Members[j], ":", "")),
+				Hwaddr:    strings.ToLower(config.Teams[i].Members[j]),
+			})
+		}
+	}
+
 	// Check if the given device is already known to networkd; if not, add it to the list
 	// of devices we need to wait for.
 	for _, dev := range devices {
@@ -2204,6 +4992,208 @@ func getExpectedNewPhysicalDevices(ctx context.Context, config *api.SystemNetwor
 	return ret
 }
 
+// physicalNIC describes a physical network interface present on the system.
+type physicalNIC struct {
+	Name       string
+	Hwaddr     string
+	PCIAddress string
+}
+
+// getPhysicalNICs returns the physical network interfaces present on the system, sorted by PCI address.
+// The permanent MAC address of each NIC is used, as the current one may have been changed.
+func getPhysicalNICs(ctx context.Context) ([]physicalNIC, error) {
+	links, err := getIPLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []physicalNIC{}
+
+	for _, link := range links {
+		// Virtual devices don't have an underlying device.
+		target, err := os.Readlink(filepath.Join("/sys/class/net", link.IfName, "device"))
+		if err != nil {
+			continue
+		}
+
+		ret = append(ret, physicalNIC{
+			Name:       link.IfName,
+			Hwaddr:     getPermanentHwaddr(link),
+			PCIAddress: filepath.Base(target),
+		})
+	}
+
+	slices.SortFunc(ret, func(a physicalNIC, b physicalNIC) int {
+		return strings.Compare(a.PCIAddress, b.PCIAddress)
+	})
+
+	return ret, nil
+}
+
+// GetPhysicalInterfaces returns the physical Ethernet interfaces present on the system, independently
+// of the applied network configuration. The permanent MAC is reported even if it has been overridden.
+func GetPhysicalInterfaces(ctx context.Context) ([]api.SystemNetworkPhysicalInterface, error) {
+	links, err := getIPLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []api.SystemNetworkPhysicalInterface{}
+
+	for _, link := range links {
+		sysPath := filepath.Join("/sys/class/net", link.IfName)
+
+		// Skip virtual devices.
+		target, err := os.Readlink(filepath.Join(sysPath, "device"))
+		if err != nil {
+			continue
+		}
+
+		// Only consider Ethernet devices (ARPHRD_ETHER), skipping wireless ones.
+		// #nosec G304
+		devType, err := os.ReadFile(filepath.Join(sysPath, "type"))
+		if err != nil || strings.TrimSpace(string(devType)) != "1" {
+			continue
+		}
+
+		_, err = os.Stat(filepath.Join(sysPath, "wireless"))
+		if err == nil {
+			continue
+		}
+
+		iface := api.SystemNetworkPhysicalInterface{
+			Name:       link.IfName,
+			Hwaddr:     getPermanentHwaddr(link),
+			PCIAddress: filepath.Base(target),
+			Speed:      "unknown",
+		}
+
+		driver, err := os.Readlink(filepath.Join(sysPath, "device", "driver"))
+		if err == nil {
+			iface.Driver = filepath.Base(driver)
+		}
+
+		// Reading the carrier or speed fails if the device is down.
+		// #nosec G304
+		carrier, err := os.ReadFile(filepath.Join(sysPath, "carrier"))
+		if err == nil {
+			iface.Carrier = strings.TrimSpace(string(carrier)) == "1"
+		}
+
+		// #nosec G304
+		speed, err := os.ReadFile(filepath.Join(sysPath, "speed"))
+		if err == nil {
+			iface.Speed = strings.TrimSpace(string(speed))
+		}
+
+		// Get the predictable name and path from udev, as the device may have been renamed.
+		output, err := subprocess.RunCommandContext(ctx, "udevadm", "info", "--query=property", "--path="+sysPath)
+		if err == nil {
+			props := map[string]string{}
+
+			for line := range strings.SplitSeq(output, "\n") {
+				key, value, found := strings.Cut(line, "=")
+				if found {
+					props[key] = value
+				}
+			}
+
+			iface.Path = props["ID_PATH"]
+
+			for _, key := range []string{"ID_NET_NAME_ONBOARD", "ID_NET_NAME_SLOT", "ID_NET_NAME_PATH"} {
+				if props[key] != "" {
+					iface.Name = props[key]
+
+					break
+				}
+			}
+		}
+
+		ret = append(ret, iface)
+	}
+
+	return ret, nil
+}
+
+// expandInterfaceGroups adds a concrete interface for each physical NIC matched by an interface group.
+// NICs already referenced by an interface or bond are skipped, so repeated expansion is a no-op.
+// As MACs may not have been resolved yet, NICs referenced by name are also considered used.
+func expandInterfaceGroups(config *api.SystemNetworkConfig, nics []physicalNIC) error {
+	usedMACs := []string{}
+
+	addUsed := func(hwaddr string) {
+		for _, nic := range nics {
+			if nic.Name == hwaddr {
+				hwaddr = nic.Hwaddr
+
+				break
+			}
+		}
+
+		usedMACs = append(usedMACs, strings.ToLower(hwaddr))
+	}
+
+	for _, iface := range config.Interfaces {
+		addUsed(iface.Hwaddr)
+	}
+
+	for _, bond := range config.Bonds {
+		for _, member := range bond.Members {
+			addUsed(member)
+		}
+	}
+
+	for _, team := range config.Teams {
+		for _, member := range team.Members {
+			addUsed(member)
+		}
+	}
+
+	for groupIndex, group := range config.InterfaceGroups {
+		if group.Name == "" {
+			return fmt.Errorf("interface group %d has no name", groupIndex)
+		}
+
+		if group.HwaddrPrefix == "" && group.PCIAddressPrefix == "" {
+			return fmt.Errorf("interface group %d has no hwaddr or PCI address prefix", groupIndex)
+		}
+
+		// Number the matching NICs, including already configured ones, so names remain stable.
+		index := 0
+
+		for _, nic := range nics {
+			if group.HwaddrPrefix != "" && !strings.HasPrefix(strings.ToLower(nic.Hwaddr), strings.ToLower(group.HwaddrPrefix)) {
+				continue
+			}
+
+			if group.PCIAddressPrefix != "" && !strings.HasPrefix(nic.PCIAddress, group.PCIAddressPrefix) {
+				continue
+			}
+
+			name := group.Name + strconv.Itoa(index)
+			index++
+
+			if slices.Contains(usedMACs, strings.ToLower(nic.Hwaddr)) {
+				continue
+			}
+
+			iface := group.Interface
+			iface.Name = name
+			iface.Hwaddr = nic.Hwaddr
+			iface.Addresses = slices.Clone(group.Interface.Addresses)
+			iface.FirewallRules = slices.Clone(group.Interface.FirewallRules)
+			iface.Roles = slices.Clone(group.Interface.Roles)
+			iface.Routes = slices.Clone(group.Interface.Routes)
+			iface.VLANTags = slices.Clone(group.Interface.VLANTags)
+
+			config.Interfaces = append(config.Interfaces, iface)
+			usedMACs = append(usedMACs, strings.ToLower(nic.Hwaddr))
+		}
+	}
+
+	return nil
+}
+
 func mangleUSBNICs(config *api.SystemNetworkConfig) {
 	usbNICRegex := regexp.MustCompile(`^enx[[:xdigit:]]{12}$`)
 
@@ -0,0 +1,125 @@
+package systemd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// GetNetworkConfigArchive returns a gzip compressed tar archive of all the network configuration files
+// generated by IncusOS, along with a "manifest.txt" listing the SHA256 checksum of each file. WireGuard keys,
+// Wake-on-LAN and VRRP passwords are redacted, so checksums of files holding them won't match those on the system.
+func GetNetworkConfigArchive() ([]byte, error) {
+	// Get the list of generated files, skipping any that don't currently exist.
+	paths := []string{SystemdTimesyncConfigFile, SystemdResolvedConfigFile, SysctlNetworkConfigFile, UdevNetworkRulesFile, IGMPProxyConfigFile}
+
+	for _, dir := range []string{SystemdNetworkConfigPath, TeamdConfigPath, KeepalivedConfigPath} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				paths = append(paths, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+
+	slices.Sort(paths)
+
+	return getNetworkConfigArchive(paths)
+}
+
+// getNetworkConfigArchive returns a gzip compressed tar archive of the provided files, with their secrets redacted.
+func getNetworkConfigArchive(paths []string) ([]byte, error) {
+	var ret bytes.Buffer
+
+	zw := gzip.NewWriter(&ret)
+	tw := tar.NewWriter(zw)
+
+	writeFile := func(name string, contents []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0o644,
+			Size: int64(len(contents)),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tw.Write(contents)
+
+		return err
+	}
+
+	var manifest strings.Builder
+
+	for _, path := range paths {
+		// #nosec G304
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		contents = redactSecrets(contents)
+
+		// Store files relative to the root, in the format expected by "sha256sum -c".
+		name := strings.TrimPrefix(path, "/")
+
+		err = writeFile(name, contents)
+		if err != nil {
+			return nil, err
+		}
+
+		_, _ = fmt.Fprintf(&manifest, "%x  %s\n", sha256.Sum256(contents), name)
+	}
+
+	err := writeFile("manifest.txt", []byte(manifest.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return ret.Bytes(), nil
+}
+
+// redactSecrets replaces the values of the WireGuard keys, Wake-on-LAN and VRRP passwords found in the provided file contents.
+func redactSecrets(contents []byte) []byte {
+	lines := strings.Split(string(contents), "\n")
+
+	for i, line := range lines {
+		for _, prefix := range []string{"PrivateKey=", "PresharedKey=", "WakeOnLanPassword=", "auth_pass "} {
+			trimmed := strings.TrimLeft(line, " \t")
+			if strings.HasPrefix(trimmed, prefix) {
+				lines[i] = line[:len(line)-len(trimmed)] + prefix + redactedSecret
+			}
+		}
+	}
+
+	return []byte(strings.Join(lines, "\n"))
+}
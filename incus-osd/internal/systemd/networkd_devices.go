@@ -0,0 +1,123 @@
+package systemd
+
+import (
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// DeviceInfo describes a kernel device generated from the network configuration.
+type DeviceInfo struct {
+	// The name of the kernel device.
+	Name string
+
+	// The kind of device, such as "bridge", "veth", "physical", "bond", "team" or "vlan".
+	Kind string
+
+	// The part the device plays for its configured device: "host" for the device carrying the addresses,
+	// "bridge", "bridge_port" for the bridge side of the veth, "uplink" or "member".
+	Role string
+
+	// The name of the configured interface, bond, team, VLAN, wireguard, tunnel, VRF or IPVLAN.
+	Config string
+}
+
+// EnumerateDeviceNames returns every device that the provided configuration generates, in configuration order.
+func EnumerateDeviceNames(networkCfg api.SystemNetworkConfig) []DeviceInfo {
+	ret := []DeviceInfo{}
+
+	add := func(name string, kind string, role string, config string) {
+		ret = append(ret, DeviceInfo{Name: name, Kind: kind, Role: role, Config: config})
+	}
+
+	addMembers := func(members []string, config string) {
+		for _, member := range members {
+			add("_p"+strings.ToLower(strings.ReplaceAll(member, ":", "")), "physical", "member", config)
+		}
+	}
+
+	for _, i := range networkCfg.Interfaces {
+		// Management interfaces have their physical device renamed directly.
+		if i.Management {
+			add("_v"+i.Name, "physical", "host", i.Name)
+
+			continue
+		}
+
+		add(i.Name, "bridge", "bridge", i.Name)
+		add("_v"+i.Name, "veth", "host", i.Name)
+		add(getVethPeerName(i), "veth", "bridge_port", i.Name)
+		add(getInterfaceDevice(i), "physical", "uplink", i.Name)
+	}
+
+	for _, b := range networkCfg.Bonds {
+		hwaddr := b.Hwaddr
+		if hwaddr == "" && len(b.Members) > 0 {
+			hwaddr = b.Members[0]
+		}
+
+		add(b.Name, "bridge", "bridge", b.Name)
+		add("_v"+b.Name, "veth", "host", b.Name)
+		add("_i"+strings.ToLower(strings.ReplaceAll(hwaddr, ":", "")), "veth", "bridge_port", b.Name)
+		add("_b"+b.Name, "bond", "uplink", b.Name)
+		addMembers(b.Members, b.Name)
+	}
+
+	for _, t := range networkCfg.Teams {
+		hwaddr := t.Hwaddr
+		if hwaddr == "" && len(t.Members) > 0 {
+			hwaddr = t.Members[0]
+		}
+
+		add(t.Name, "bridge", "bridge", t.Name)
+		add("_v"+t.Name, "veth", "host", t.Name)
+		add("_i"+strings.ToLower(strings.ReplaceAll(hwaddr, ":", "")), "veth", "bridge_port", t.Name)
+		add("_a"+t.Name, "team", "uplink", t.Name)
+		addMembers(t.Members, t.Name)
+	}
+
+	for _, v := range networkCfg.VLANs {
+		add(v.Name, "vlan", "host", v.Name)
+	}
+
+	for _, w := range networkCfg.Wireguard {
+		add(w.Name, "wireguard", "host", w.Name)
+	}
+
+	for _, t := range networkCfg.Tunnels {
+		// GRETAP tunnels are bridged like interfaces, the tunnel device acting as the uplink.
+		if t.Kind != "gretap" {
+			add(t.Name, "tunnel", "host", t.Name)
+
+			continue
+		}
+
+		add(t.Name, "bridge", "bridge", t.Name)
+		add("_v"+t.Name, "veth", "host", t.Name)
+		add("_i"+t.Name, "veth", "bridge_port", t.Name)
+		add("_t"+t.Name, "tunnel", "uplink", t.Name)
+	}
+
+	for _, v := range networkCfg.VRFs {
+		add(v.Name, "vrf", "host", v.Name)
+	}
+
+	for _, v := range networkCfg.IPVLANs {
+		add(v.Name, "ipvlan", "host", v.Name)
+	}
+
+	return ret
+}
+
+// getHostDevices returns a map of each configured device name to the name of the device carrying its addresses.
+func getHostDevices(networkCfg api.SystemNetworkConfig) map[string]string {
+	ret := map[string]string{}
+
+	for _, device := range EnumerateDeviceNames(networkCfg) {
+		if device.Role == "host" {
+			ret[device.Config] = device.Name
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,146 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+const (
+	// NetworkEventLinkAdded is sent when a new device appears.
+	NetworkEventLinkAdded = "link-added"
+
+	// NetworkEventLinkRemoved is sent when a device disappears.
+	NetworkEventLinkRemoved = "link-removed"
+
+	// NetworkEventLinkState is sent when the operational state of a device changes.
+	NetworkEventLinkState = "link-state"
+
+	// NetworkEventAddressAdded is sent when a device acquires an address.
+	NetworkEventAddressAdded = "address-added"
+
+	// NetworkEventAddressRemoved is sent when a device loses an address.
+	NetworkEventAddressRemoved = "address-removed"
+
+	// NetworkEventConfigurationApplied is sent when a network configuration was successfully applied.
+	NetworkEventConfigurationApplied = "configuration-applied"
+
+	// NetworkEventConfigurationFailed is sent when a network configuration failed to apply.
+	NetworkEventConfigurationFailed = "configuration-failed"
+)
+
+// WatchNetworkEvents polls the state of all network devices at the provided interval, calling the handler for
+// every link or address change until the context is cancelled or the handler returns an error. Failures to
+// poll the devices are logged and retried at the next interval.
+func WatchNetworkEvents(ctx context.Context, interval time.Duration, handler func(api.SystemNetworkEvent) error) error {
+	previous, err := getIPLinks(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := getIPLinks(ctx)
+		if err != nil {
+			// The context may have been cancelled while the command was running.
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			// Keep polling, as the failure may be transient.
+			slog.WarnContext(ctx, "Failed to get the network devices", "err", err)
+
+			continue
+		}
+
+		for _, event := range diffNetworkLinks(previous, current, time.Now()) {
+			err := handler(event)
+			if err != nil {
+				return err
+			}
+		}
+
+		previous = current
+	}
+}
+
+// diffNetworkLinks returns the events needed to go from the previous to the current list of links.
+func diffNetworkLinks(previous []ipLink, current []ipLink, now time.Time) []api.SystemNetworkEvent {
+	events := []api.SystemNetworkEvent{}
+
+	addresses := func(link ipLink) []string {
+		ret := []string{}
+
+		for _, addr := range link.AddrInfo {
+			if addr.Family != "inet" && addr.Family != "inet6" {
+				continue
+			}
+
+			ret = append(ret, fmt.Sprintf("%s/%d", addr.Local, addr.PrefixLen))
+		}
+
+		return ret
+	}
+
+	for _, link := range current {
+		if link.IfName == "lo" {
+			continue
+		}
+
+		idx := slices.IndexFunc(previous, func(l ipLink) bool { return l.IfName == link.IfName })
+		if idx == -1 {
+			events = append(events, api.SystemNetworkEvent{Timestamp: now, Type: NetworkEventLinkAdded, Device: link.IfName, State: link.OperState})
+
+			for _, addr := range addresses(link) {
+				events = append(events, api.SystemNetworkEvent{Timestamp: now, Type: NetworkEventAddressAdded, Device: link.IfName, Address: addr})
+			}
+
+			continue
+		}
+
+		old := previous[idx]
+
+		if old.OperState != link.OperState {
+			events = append(events, api.SystemNetworkEvent{Timestamp: now, Type: NetworkEventLinkState, Device: link.IfName, State: link.OperState})
+		}
+
+		oldAddresses := addresses(old)
+		newAddresses := addresses(link)
+
+		for _, addr := range newAddresses {
+			if !slices.Contains(oldAddresses, addr) {
+				events = append(events, api.SystemNetworkEvent{Timestamp: now, Type: NetworkEventAddressAdded, Device: link.IfName, Address: addr})
+			}
+		}
+
+		for _, addr := range oldAddresses {
+			if !slices.Contains(newAddresses, addr) {
+				events = append(events, api.SystemNetworkEvent{Timestamp: now, Type: NetworkEventAddressRemoved, Device: link.IfName, Address: addr})
+			}
+		}
+	}
+
+	for _, link := range previous {
+		if link.IfName == "lo" {
+			continue
+		}
+
+		if !slices.ContainsFunc(current, func(l ipLink) bool { return l.IfName == link.IfName }) {
+			events = append(events, api.SystemNetworkEvent{Timestamp: now, Type: NetworkEventLinkRemoved, Device: link.IfName})
+		}
+	}
+
+	return events
+}
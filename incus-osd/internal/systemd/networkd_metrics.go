@@ -0,0 +1,74 @@
+package systemd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// GetNetworkMetrics renders the network state of each device in the Prometheus text exposition format.
+func GetNetworkMetrics(networkState api.SystemNetworkState) string {
+	type metric struct {
+		name  string
+		kind  string
+		help  string
+		value func(state api.SystemNetworkInterfaceState) int
+	}
+
+	metrics := []metric{
+		{"incusos_network_interface_carrier", "gauge", "Whether the network device has a carrier.", func(state api.SystemNetworkInterfaceState) int {
+			return boolToInt(!slices.Contains([]string{"", "missing", "off", "no-carrier"}, state.State))
+		}},
+		{"incusos_network_interface_online", "gauge", "Whether the network device has a routable address.", func(state api.SystemNetworkInterfaceState) int {
+			return boolToInt(state.State == "routable")
+		}},
+		{"incusos_network_interface_addresses", "gauge", "Number of addresses on the network device.", func(state api.SystemNetworkInterfaceState) int {
+			return len(state.Addresses)
+		}},
+		{"incusos_network_interface_receive_bytes_total", "counter", "Bytes received by the network device.", func(state api.SystemNetworkInterfaceState) int {
+			return state.Stats.RXBytes
+		}},
+		{"incusos_network_interface_receive_errors_total", "counter", "Receive errors on the network device.", func(state api.SystemNetworkInterfaceState) int {
+			return state.Stats.RXErrors
+		}},
+		{"incusos_network_interface_transmit_bytes_total", "counter", "Bytes transmitted by the network device.", func(state api.SystemNetworkInterfaceState) int {
+			return state.Stats.TXBytes
+		}},
+		{"incusos_network_interface_transmit_errors_total", "counter", "Transmit errors on the network device.", func(state api.SystemNetworkInterfaceState) int {
+			return state.Stats.TXErrors
+		}},
+	}
+
+	names := make([]string, 0, len(networkState.Interfaces))
+	for name := range networkState.Interfaces {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var sb strings.Builder
+
+	for _, m := range metrics {
+		fmt.Fprintf(&sb, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(&sb, "# TYPE %s %s\n", m.name, m.kind)
+
+		for _, name := range names {
+			state := networkState.Interfaces[name]
+
+			fmt.Fprintf(&sb, "%s{name=%q,type=%q} %d\n", m.name, name, state.Type, m.value(state))
+		}
+	}
+
+	return sb.String()
+}
+
+// boolToInt converts a boolean to a metric value.
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,123 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus/v7/shared/subprocess"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// minimalNetworkFile is the name of the .network file used by the minimal early boot network.
+const minimalNetworkFile = "99-incus-osd-minimal.network"
+
+// FetchInitialNetworkConfiguration retrieves the network configuration from the remote source of the seed on
+// first boot. Basic connectivity is quickly provided by configuring DHCP and SLAAC directly on all physical
+// NICs, without any bridge or veth. This minimal network is replaced by the next ApplyNetworkConfiguration.
+func FetchInitialNetworkConfiguration(ctx context.Context, source api.SystemNetworkRemoteSource) (*api.SystemNetworkConfig, error) {
+	nics, err := getPhysicalNICs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nics) == 0 {
+		return nil, errors.New("no physical NIC to fetch the network configuration through")
+	}
+
+	err = os.MkdirAll(SystemdNetworkConfigPath, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G306
+	err = os.WriteFile(filepath.Join(SystemdNetworkConfigPath, minimalNetworkFile), []byte(generateMinimalNetworkContents(nics)), 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	err = RestartUnit(ctx, "systemd-networkd")
+	if err != nil {
+		return nil, err
+	}
+
+	networkCfg, err := FetchNetworkConfiguration(ctx, source, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep fetching from the same source unless the configuration defines its own.
+	if networkCfg.RemoteSource == nil {
+		networkCfg.RemoteSource = &source
+	}
+
+	return networkCfg, nil
+}
+
+// generateMinimalNetworkContents returns the .network file configuring DHCP and SLAAC directly on the provided NICs.
+func generateMinimalNetworkContents(nics []physicalNIC) string {
+	hwaddrs := make([]string, 0, len(nics))
+	for _, nic := range nics {
+		hwaddrs = append(hwaddrs, nic.Hwaddr)
+	}
+
+	return fmt.Sprintf(`[Match]
+PermanentMACAddress=%s
+
+[Link]
+RequiredForOnline=no
+
+[Network]
+DHCP=yes
+IPv6AcceptRA=true
+LinkLocalAddressing=ipv6
+`, strings.Join(hwaddrs, " "))
+}
+
+// removeMinimalNetworkConfiguration removes the minimal early boot network, if present. The physical
+// NICs are brought back down, as the kernel doesn't allow udev to rename them while they're up.
+func removeMinimalNetworkConfiguration(ctx context.Context) error {
+	path := filepath.Join(SystemdNetworkConfigPath, minimalNetworkFile)
+
+	_, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = subprocess.RunCommandContext(ctx, "networkctl", "reload")
+	if err != nil {
+		return err
+	}
+
+	nics, err := getPhysicalNICs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, nic := range nics {
+		_, err := subprocess.RunCommandContext(ctx, "ip", "address", "flush", "dev", nic.Name)
+		if err != nil {
+			return err
+		}
+
+		_, err = subprocess.RunCommandContext(ctx, "ip", "link", "set", "dev", nic.Name, "down")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/subprocess"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// netnsTestName is the name of the throwaway network namespace used to test network configurations.
+const netnsTestName = "incus-osd-test"
+
+var muNetnsTest sync.Mutex
+
+// netnsTestScript runs a private systemd-networkd inside the test namespace, using its own runtime
+// directories (in the mount namespace set up by "ip netns exec") so it doesn't interact with the
+// system instance, udev or the system D-Bus. It waits until every expected device has settled, or
+// the timeout expires, then reports the admin state of every link.
+const netnsTestScript = `set -e
+mount -t tmpfs tmpfs /run/systemd
+mount -t tmpfs tmpfs /run/udev
+mount -t tmpfs tmpfs /run/dbus
+mkdir -p /run/systemd/network /run/systemd/netif
+cp %s/* /run/systemd/network/
+chown systemd-network:systemd-network /run/systemd/netif
+/usr/lib/systemd/systemd-networkd &
+for i in $(seq %d); do
+    sleep 1
+    pending=0
+    for name in %s; do
+        state=$(sed -n 's/^ADMIN_STATE=//p' "/run/systemd/netif/links/$(cat "/sys/class/net/${name}/ifindex" 2>/dev/null)" 2>/dev/null || true)
+        case "${state}" in
+            configured|configuring|failed|unmanaged|linger) ;;
+            *) pending=1 ;;
+        esac
+    done
+    [ "${pending}" = "0" ] && break
+done
+kill $!
+for dev in /sys/class/net/*; do
+    state=$(sed -n 's/^ADMIN_STATE=//p' "/run/systemd/netif/links/$(cat "${dev}/ifindex")" 2>/dev/null || true)
+    echo "$(basename "${dev}") ${state:-unmanaged}"
+done
+`
+
+// CheckNetworkConfigurationInNamespace brings up a network configuration in a throwaway network namespace,
+// with physical NICs replaced by dummy devices, and reports any device that systemd-networkd failed to
+// create or configure. This validates the bridge, bond, VLAN and veth topology without touching the live network.
+// The configuration is first checked and resolved by CheckNetworkConfiguration.
+func CheckNetworkConfigurationInNamespace(ctx context.Context, networkCfg *api.SystemNetworkConfig, timeout time.Duration) error {
+	err := CheckNetworkConfiguration(ctx, networkCfg)
+	if err != nil {
+		return err
+	}
+
+	muNetnsTest.Lock()
+	defer muNetnsTest.Unlock()
+
+	cfgDir, err := os.MkdirTemp("", "incus-osd-netns-")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.RemoveAll(cfgDir) }()
+
+	cfgs := slices.Concat(generateNetdevFileContents(*networkCfg), generateNetworkFileContents(*networkCfg))
+
+	expectedDevices := []string{}
+	nameRegex := regexp.MustCompile(`(?m)^Name=(.+)$`)
+
+	for _, cfg := range cfgs {
+		// #nosec G306
+		err := os.WriteFile(filepath.Join(cfgDir, cfg.Name), []byte(cfg.Contents), 0o644)
+		if err != nil {
+			return err
+		}
+
+		match := nameRegex.FindStringSubmatch(cfg.Contents)
+		if strings.HasSuffix(cfg.Name, ".network") && match != nil && !slices.Contains(expectedDevices, match[1]) {
+			expectedDevices = append(expectedDevices, match[1])
+		}
+	}
+
+	// Start from a fresh namespace, removing any left over from an interrupted test.
+	_, _ = subprocess.RunCommandContext(ctx, "ip", "netns", "del", netnsTestName)
+
+	_, err = subprocess.RunCommandContext(ctx, "ip", "netns", "add", netnsTestName)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_, _ = subprocess.RunCommandContext(context.WithoutCancel(ctx), "ip", "netns", "del", netnsTestName)
+	}()
+
+	// Replace the physical NICs with dummy devices.
+	devices := getNetnsTestDevices(*networkCfg)
+
+	for name, hwaddr := range devices {
+		_, err := subprocess.RunCommandContext(ctx, "ip", "-n", netnsTestName, "link", "add", name, "address", hwaddr, "type", "dummy")
+		if err != nil {
+			return err
+		}
+	}
+
+	output, err := subprocess.RunCommandContext(ctx, "ip", "netns", "exec", netnsTestName, "sh", "-c", fmt.Sprintf(netnsTestScript, cfgDir, int(timeout.Seconds()), strings.Join(expectedDevices, " ")))
+	if err != nil {
+		return err
+	}
+
+	states := map[string]string{}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		name, state, found := strings.Cut(line, " ")
+		if found {
+			states[name] = state
+		}
+	}
+
+	for _, dev := range expectedDevices {
+		state, ok := states[dev]
+		if !ok {
+			return fmt.Errorf("device '%s' wasn't created", dev)
+		}
+
+		if state != "configured" && state != "configuring" {
+			return fmt.Errorf("device '%s' failed to configure (state %s)", dev, state)
+		}
+	}
+
+	return nil
+}
+
+// getNetnsTestDevices returns the dummy devices, and their MAC address, standing in for the physical NICs
+// referenced by a resolved network configuration.
+func getNetnsTestDevices(networkCfg api.SystemNetworkConfig) map[string]string {
+	devices := map[string]string{}
+
+	for _, i := range networkCfg.Interfaces {
+		devices[getInterfaceDevice(i)] = i.Hwaddr
+	}
+
+	for _, b := range networkCfg.Bonds {
+		for _, hwaddr := range b.Members {
+			devices["_p"+strings.ToLower(strings.ReplaceAll(hwaddr, ":", ""))] = hwaddr
+		}
+	}
+
+	for _, t := range networkCfg.Teams {
+		for _, hwaddr := range t.Members {
+			devices["_p"+strings.ToLower(strings.ReplaceAll(hwaddr, ":", ""))] = hwaddr
+		}
+	}
+
+	return devices
+}
@@ -0,0 +1,202 @@
+package systemd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus/v7/shared/subprocess"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// ipRoute holds the subset of a route's "ip -json" output that we care about.
+type ipRoute struct {
+	Dst      string      `json:"dst"`
+	Dev      string      `json:"dev"`
+	NextHops []ipNextHop `json:"nexthops"`
+}
+
+// ipNextHop holds the subset of a multipath route next-hop's "ip -json" output that we care about.
+type ipNextHop struct {
+	Dev string `json:"dev"`
+}
+
+// reconcileDevice holds the applied configuration of a device to be compared against its live state.
+type reconcileDevice struct {
+	name      string
+	dev       string
+	addresses []string
+	routes    []api.SystemNetworkRoute
+	mtu       int
+}
+
+// GetNetworkReconciliation compares the provided network configuration against the live state of each
+// configured device and returns any difference in addresses, routes or MTU.
+func GetNetworkReconciliation(ctx context.Context, networkCfg *api.SystemNetworkConfig) ([]api.SystemNetworkReconciliation, error) {
+	links, err := getIPLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := getIPRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []reconcileDevice{}
+	hostDevices := getHostDevices(*networkCfg)
+
+	for _, i := range networkCfg.Interfaces {
+		devices = append(devices, reconcileDevice{i.Name, hostDevices[i.Name], i.Addresses, i.Routes, i.MTU})
+	}
+
+	for _, b := range networkCfg.Bonds {
+		devices = append(devices, reconcileDevice{b.Name, hostDevices[b.Name], b.Addresses, b.Routes, b.MTU})
+	}
+
+	for _, t := range networkCfg.Teams {
+		devices = append(devices, reconcileDevice{t.Name, hostDevices[t.Name], t.Addresses, t.Routes, t.MTU})
+	}
+
+	for _, v := range networkCfg.VLANs {
+		devices = append(devices, reconcileDevice{v.Name, hostDevices[v.Name], v.Addresses, v.Routes, v.MTU})
+	}
+
+	return reconcileNetworkDevices(devices, links, routes), nil
+}
+
+// getIPRoutes returns all IPv4 and IPv6 routes, across all routing tables, as reported by "ip -json route show".
+func getIPRoutes(ctx context.Context) ([]ipRoute, error) {
+	ret := []ipRoute{}
+
+	for _, family := range []string{"-4", "-6"} {
+		output, err := subprocess.RunCommandContext(ctx, "ip", family, "-json", "route", "show", "table", "all")
+		if err != nil {
+			return nil, err
+		}
+
+		routes := []ipRoute{}
+
+		err = json.Unmarshal([]byte(output), &routes)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, route := range routes {
+			route.Dst = normalizeRouteDestination(route.Dst, family == "-6")
+			ret = append(ret, route)
+		}
+	}
+
+	return ret, nil
+}
+
+// reconcileNetworkDevices compares the expected state of each device against the provided live links and routes.
+func reconcileNetworkDevices(devices []reconcileDevice, links []ipLink, routes []ipRoute) []api.SystemNetworkReconciliation {
+	ret := make([]api.SystemNetworkReconciliation, 0, len(devices))
+
+	for _, device := range devices {
+		status := api.SystemNetworkReconciliation{Device: device.name}
+
+		idx := slices.IndexFunc(links, func(l ipLink) bool { return l.IfName == device.dev })
+		if idx == -1 {
+			status.Missing = true
+			ret = append(ret, status)
+
+			continue
+		}
+
+		link := links[idx]
+
+		// Compare the MTU, if one was configured.
+		if device.mtu != 0 && device.mtu != link.MTU {
+			status.ExpectedMTU = device.mtu
+			status.MTU = link.MTU
+		}
+
+		// Compare the static addresses. Extra addresses are only reported when no dynamic addressing is used.
+		liveAddresses := []string{}
+
+		for _, addr := range link.AddrInfo {
+			if (addr.Family != "inet" && addr.Family != "inet6") || isLinkLocalAddress(addr.Local) {
+				continue
+			}
+
+			liveAddresses = append(liveAddresses, fmt.Sprintf("%s/%d", addr.Local, addr.PrefixLen))
+		}
+
+		dynamic := false
+
+		for _, addr := range device.addresses {
+			if addr == "dhcp4" || addr == "dhcp6" || addr == "slaac" {
+				dynamic = true
+
+				continue
+			}
+
+			// Link-local addresses aren't compared.
+			if addr == "link-local" {
+				continue
+			}
+
+			if !slices.Contains(liveAddresses, addr) {
+				status.MissingAddresses = append(status.MissingAddresses, addr)
+			}
+		}
+
+		if !dynamic {
+			for _, addr := range liveAddresses {
+				if !slices.Contains(device.addresses, addr) {
+					status.ExtraAddresses = append(status.ExtraAddresses, addr)
+				}
+			}
+		}
+
+		// Compare the routes by destination.
+		for _, route := range device.routes {
+			dst := normalizeRouteDestination(route.To, strings.Contains(route.To, ":"))
+
+			found := slices.ContainsFunc(routes, func(r ipRoute) bool {
+				if r.Dst != dst {
+					return false
+				}
+
+				return r.Dev == device.dev || slices.Contains(r.NextHops, ipNextHop{Dev: device.dev})
+			})
+
+			if !found {
+				status.MissingRoutes = append(status.MissingRoutes, route.To)
+			}
+		}
+
+		status.InSync = status.ExpectedMTU == 0 && len(status.MissingAddresses) == 0 && len(status.ExtraAddresses) == 0 && len(status.MissingRoutes) == 0
+		ret = append(ret, status)
+	}
+
+	return ret
+}
+
+// normalizeRouteDestination returns the provided route destination in CIDR notation.
+func normalizeRouteDestination(dst string, ipv6 bool) string {
+	switch {
+	case dst == "default" && ipv6:
+		return "::/0"
+	case dst == "default":
+		return "0.0.0.0/0"
+	case !strings.Contains(dst, "/") && ipv6:
+		dst += "/128"
+	case !strings.Contains(dst, "/"):
+		dst += "/32"
+	}
+
+	_, subnet, err := net.ParseCIDR(dst)
+	if err != nil {
+		return dst
+	}
+
+	return subnet.String()
+}
@@ -0,0 +1,157 @@
+package systemd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	incustls "github.com/lxc/incus/v7/shared/tls"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// errInsecureRedirect is returned when the remote source redirects to a non-https URL.
+var errInsecureRedirect = errors.New("refusing redirect to non-https URL")
+
+// FetchNetworkConfiguration retrieves a network configuration from a remote HTTPS endpoint. Connection
+// failures and server errors are retried with an exponential backoff, up to the provided number of attempts.
+func FetchNetworkConfiguration(ctx context.Context, source api.SystemNetworkRemoteSource, attempts int) (*api.SystemNetworkConfig, error) {
+	return fetchNetworkConfigurationWithBackoff(ctx, source, attempts, time.Second)
+}
+
+// fetchNetworkConfigurationWithBackoff retrieves a network configuration, doubling the provided delay between attempts.
+func fetchNetworkConfigurationWithBackoff(ctx context.Context, source api.SystemNetworkRemoteSource, attempts int, delay time.Duration) (*api.SystemNetworkConfig, error) {
+	client, err := getRemoteSourceClient(source)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		networkCfg, retryable, err := fetchNetworkConfiguration(ctx, client, source)
+		if err == nil {
+			return networkCfg, nil
+		}
+
+		if !retryable || attempt >= attempts {
+			return nil, fmt.Errorf("failed to fetch network configuration from '%s': %w", source.URL, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// getRemoteSourceClient returns an HTTP client using the TLS settings of the remote source.
+func getRemoteSourceClient(source api.SystemNetworkRemoteSource) (*http.Client, error) {
+	u, err := url.Parse(source.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid remote network configuration URL '%s'", source.URL)
+	}
+
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("remote network configuration URL '%s' must use https", source.URL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if source.ServerCertificate != "" {
+		certBlock, _ := pem.Decode([]byte(source.ServerCertificate))
+		if certBlock == nil {
+			return nil, errors.New("invalid server certificate")
+		}
+
+		serverCert, err := x509.ParseCertificate(certBlock.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server certificate: %w", err)
+		}
+
+		incustls.TLSConfigWithTrustedCert(tlsConfig, serverCert)
+	}
+
+	if source.ClientCertificate != "" || source.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(source.ClientCertificate), []byte(source.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+		// Never let a redirect downgrade the connection, which would expose the bearer token.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("%w '%s'", errInsecureRedirect, req.URL.Redacted())
+			}
+
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+// fetchNetworkConfiguration makes a single attempt at retrieving the network configuration, also
+// reporting whether a failure may be transient.
+func fetchNetworkConfiguration(ctx context.Context, client *http.Client, source api.SystemNetworkRemoteSource) (*api.SystemNetworkConfig, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if source.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+source.BearerToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, !errors.Is(err, errInsecureRedirect), err
+	}
+
+	defer resp.Body.Close()
+
+	// Only server errors and rate limiting are considered transient.
+	if resp.StatusCode != http.StatusOK {
+		retryable := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+
+		return nil, retryable, fmt.Errorf("unexpected status '%s'", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return nil, true, err
+	}
+
+	networkCfg := &api.SystemNetworkConfig{}
+
+	err = json.Unmarshal(body, networkCfg)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid network configuration: %w", err)
+	}
+
+	return networkCfg, false, nil
+}
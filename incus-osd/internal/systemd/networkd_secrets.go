@@ -0,0 +1,162 @@
+package systemd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// redactedSecret replaces keys, tokens and passwords in configurations returned over the API.
+const redactedSecret = "[redacted]"
+
+// RedactNetworkSecrets returns a copy of the network configuration with the 802.1X keys and passwords,
+// the VRRP passwords, the remote source credentials and the webhook secret redacted.
+func RedactNetworkSecrets(networkCfg *api.SystemNetworkConfig) *api.SystemNetworkConfig {
+	if networkCfg == nil {
+		return nil
+	}
+
+	ret := *networkCfg
+	ret.Interfaces = slices.Clone(networkCfg.Interfaces)
+	ret.Bonds = slices.Clone(networkCfg.Bonds)
+	ret.VLANs = slices.Clone(networkCfg.VLANs)
+
+	redact := func(secret string) string {
+		if secret == "" {
+			return ""
+		}
+
+		return redactedSecret
+	}
+
+	for i, iface := range ret.Interfaces {
+		if iface.Dot1X == nil {
+			continue
+		}
+
+		dot1x := *iface.Dot1X
+		dot1x.ClientKey = redact(dot1x.ClientKey)
+		dot1x.ClientKeyPassword = redact(dot1x.ClientKeyPassword)
+		dot1x.Password = redact(dot1x.Password)
+		ret.Interfaces[i].Dot1X = &dot1x
+	}
+
+	redactVRRP := func(vrrps []api.SystemNetworkVRRP) []api.SystemNetworkVRRP {
+		vrrps = slices.Clone(vrrps)
+
+		for i := range vrrps {
+			vrrps[i].AuthPassword = redact(vrrps[i].AuthPassword)
+		}
+
+		return vrrps
+	}
+
+	for i := range ret.Interfaces {
+		ret.Interfaces[i].VRRP = redactVRRP(ret.Interfaces[i].VRRP)
+	}
+
+	for i := range ret.Bonds {
+		ret.Bonds[i].VRRP = redactVRRP(ret.Bonds[i].VRRP)
+	}
+
+	for i := range ret.VLANs {
+		ret.VLANs[i].VRRP = redactVRRP(ret.VLANs[i].VRRP)
+	}
+
+	if ret.RemoteSource != nil {
+		source := *ret.RemoteSource
+		source.BearerToken = redact(source.BearerToken)
+		source.ClientKey = redact(source.ClientKey)
+
+		// The client certificate may be bundled with its key.
+		if strings.Contains(source.ClientCertificate, "PRIVATE KEY") {
+			source.ClientCertificate = redactedSecret
+		}
+
+		ret.RemoteSource = &source
+	}
+
+	if ret.Webhook != nil {
+		webhook := *ret.Webhook
+		webhook.Secret = redact(webhook.Secret)
+		ret.Webhook = &webhook
+	}
+
+	return &ret
+}
+
+// RestoreNetworkSecrets replaces any redacted secret in the new network configuration with the value
+// currently configured, so a configuration retrieved over the API can be sent back unchanged.
+func RestoreNetworkSecrets(newCfg *api.SystemNetworkConfig, currentCfg *api.SystemNetworkConfig) {
+	if newCfg == nil || currentCfg == nil {
+		return
+	}
+
+	restore := func(secret *string, current string) {
+		if *secret == redactedSecret {
+			*secret = current
+		}
+	}
+
+	for i, iface := range newCfg.Interfaces {
+		if iface.Dot1X == nil {
+			continue
+		}
+
+		idx := slices.IndexFunc(currentCfg.Interfaces, func(c api.SystemNetworkInterface) bool { return c.Name == iface.Name && c.Dot1X != nil })
+		if idx == -1 {
+			continue
+		}
+
+		current := currentCfg.Interfaces[idx].Dot1X
+		dot1x := newCfg.Interfaces[i].Dot1X
+
+		restore(&dot1x.ClientKey, current.ClientKey)
+		restore(&dot1x.ClientKeyPassword, current.ClientKeyPassword)
+		restore(&dot1x.Password, current.Password)
+	}
+
+	// VRRP passwords are restored from the virtual router with the same ID on the device of the same name.
+	restoreVRRP := func(vrrps []api.SystemNetworkVRRP, current []api.SystemNetworkVRRP) {
+		for i, vrrp := range vrrps {
+			idx := slices.IndexFunc(current, func(c api.SystemNetworkVRRP) bool { return c.VRID == vrrp.VRID })
+			if idx == -1 {
+				continue
+			}
+
+			restore(&vrrps[i].AuthPassword, current[idx].AuthPassword)
+		}
+	}
+
+	for _, iface := range newCfg.Interfaces {
+		idx := slices.IndexFunc(currentCfg.Interfaces, func(c api.SystemNetworkInterface) bool { return c.Name == iface.Name })
+		if idx != -1 {
+			restoreVRRP(iface.VRRP, currentCfg.Interfaces[idx].VRRP)
+		}
+	}
+
+	for _, bond := range newCfg.Bonds {
+		idx := slices.IndexFunc(currentCfg.Bonds, func(c api.SystemNetworkBond) bool { return c.Name == bond.Name })
+		if idx != -1 {
+			restoreVRRP(bond.VRRP, currentCfg.Bonds[idx].VRRP)
+		}
+	}
+
+	for _, vlan := range newCfg.VLANs {
+		idx := slices.IndexFunc(currentCfg.VLANs, func(c api.SystemNetworkVLAN) bool { return c.Name == vlan.Name })
+		if idx != -1 {
+			restoreVRRP(vlan.VRRP, currentCfg.VLANs[idx].VRRP)
+		}
+	}
+
+	if newCfg.RemoteSource != nil && currentCfg.RemoteSource != nil {
+		restore(&newCfg.RemoteSource.BearerToken, currentCfg.RemoteSource.BearerToken)
+		restore(&newCfg.RemoteSource.ClientCertificate, currentCfg.RemoteSource.ClientCertificate)
+		restore(&newCfg.RemoteSource.ClientKey, currentCfg.RemoteSource.ClientKey)
+	}
+
+	if newCfg.Webhook != nil && currentCfg.Webhook != nil {
+		restore(&newCfg.Webhook.Secret, currentCfg.Webhook.Secret)
+	}
+}
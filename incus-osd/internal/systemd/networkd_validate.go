@@ -1,386 +1,1855 @@
 package systemd
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"net"
+	"net/url"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lxc/incus-os/incus-osd/api"
 )
 
-func validateInterfaces(interfaces []api.SystemNetworkInterface, requireValidMAC bool) error {
+// Machine-readable codes carried by a ValidationError.
+const (
+	ValidationCodeInvalid    = "invalid"
+	ValidationCodeMissing    = "missing"
+	ValidationCodeOutOfRange = "out_of_range"
+)
+
+// ValidationError is returned when a device fails validation, identifying the offending device and field.
+type ValidationError struct {
+	Kind   string
+	Index  int
+	Device string
+	Field  string
+	Code   string
+	Err    error
+}
+
+func newValidationError(kind string, index int, device string, field string, code string, err error) error {
+	return &ValidationError{Kind: kind, Index: index, Device: device, Field: field, Code: code, Err: err}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s %d %s", e.Kind, e.Index, e.Err.Error())
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// API returns the API representation of the validation error.
+func (e *ValidationError) API() api.SystemNetworkValidationError {
+	return api.SystemNetworkValidationError{
+		Code:    e.Code,
+		Device:  e.Device,
+		Field:   e.Field,
+		Kind:    e.Kind,
+		Message: e.Error(),
+	}
+}
+
+// ValidationErrors returns the API representation of every ValidationError contained in err.
+func ValidationErrors(err error) []api.SystemNetworkValidationError {
+	ret := []api.SystemNetworkValidationError{}
+
+	joined, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint
+	if ok {
+		for _, e := range joined.Unwrap() {
+			ret = append(ret, ValidationErrors(e)...)
+		}
+
+		return ret
+	}
+
+	var validationErr *ValidationError
+
+	if errors.As(err, &validationErr) {
+		ret = append(ret, validationErr.API())
+	}
+
+	return ret
+}
+
+func validateInterfaces(interfaces []api.SystemNetworkInterface, vlans []api.SystemNetworkVLAN, requireValidMAC bool) error {
+	var errs []error
+
+	managementInterface := ""
+
 	for index, iface := range interfaces {
-		err := validateName(iface.Name)
+		err := validateName(iface.Name, "_v")
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "name", ValidationCodeInvalid, err))
+		}
+
+		err = validateExtraOptions(iface.ExtraOptions)
 		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "extra_options", ValidationCodeInvalid, err))
 		}
 
 		err = validateMTU(iface.MTU)
 		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "mtu", ValidationCodeOutOfRange, err))
 		}
 
 		err = validateRoles(iface.Roles)
 		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "roles", ValidationCodeInvalid, err))
 		}
 
 		err = validateFirewall(iface.FirewallRules)
 		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "firewall_rules", ValidationCodeInvalid, err))
 		}
 
 		for addressIndex, address := range iface.Addresses {
-			err := validateAddressWithCIDR(address)
+			err := validateDeviceAddress(address)
 			if err != nil {
-				return fmt.Errorf("interface %d address %d %s", index, addressIndex, err.Error())
+				errs = append(errs, newValidationError("interface", index, iface.Name, "addresses", ValidationCodeInvalid, fmt.Errorf("address %d %s", addressIndex, err.Error())))
 			}
 		}
 
+		err = validateLinkLocalOnly(iface.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "addresses", ValidationCodeInvalid, err))
+		}
+
 		err = validateRequiredForOnline(iface.RequiredForOnline)
 		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "required_for_online", ValidationCodeInvalid, err))
 		}
 
 		for routeIndex, route := range iface.Routes {
 			err := validateAddressWithCIDR(route.To)
 			if err != nil {
-				return fmt.Errorf("interface %d route %d 'To' %s", index, routeIndex, err.Error())
+				errs = append(errs, newValidationError("interface", index, iface.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d 'To' %s", routeIndex, err.Error())))
 			}
 
-			err = validateAddress(route.Via)
+			err = validateRouteVia(route, iface.Addresses)
 			if err != nil {
-				return fmt.Errorf("interface %d route %d 'Via' %s", index, routeIndex, err.Error())
+				errs = append(errs, newValidationError("interface", index, iface.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+
+			err = validateRouteSource(route, iface.Addresses)
+			if err != nil {
+				errs = append(errs, newValidationError("interface", index, iface.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+
+			err = validateRouteBFD(route)
+			if err != nil {
+				errs = append(errs, newValidationError("interface", index, iface.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+
+			err = validateRouteMTU(route, iface.MTU)
+			if err != nil {
+				errs = append(errs, newValidationError("interface", index, iface.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
 			}
 		}
 
 		err = validateHwaddr(iface.Hwaddr, requireValidMAC)
 		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "hwaddr", ValidationCodeInvalid, err))
 		}
 
 		err = validateEthernet(iface.Ethernet)
 		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ethernet", ValidationCodeInvalid, err))
 		}
-	}
-
-	return nil
-}
 
-func validateBonds(bonds []api.SystemNetworkBond, requireValidMAC bool) error {
-	for index, bond := range bonds {
-		err := validateName(bond.Name)
+		err = validateAlternativeNames(iface.AlternativeNames)
 		if err != nil {
-			return fmt.Errorf("bond %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "alternative_names", ValidationCodeInvalid, err))
 		}
 
-		err = validateMode(bond.Mode)
+		err = validateMACAddressPolicy(iface.MACAddressPolicy, iface.MACAddress)
 		if err != nil {
-			return fmt.Errorf("bond %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "mac_address_policy", ValidationCodeInvalid, err))
 		}
 
-		err = validateMTU(bond.MTU)
+		err = validateDot1X(iface.Dot1X)
 		if err != nil {
-			return fmt.Errorf("bond %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "dot1x", ValidationCodeInvalid, err))
 		}
 
-		err = validateRoles(bond.Roles)
+		err = validateQoS(iface.QoS)
 		if err != nil {
-			return fmt.Errorf("bond %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "qos", ValidationCodeInvalid, err))
 		}
 
-		err = validateFirewall(bond.FirewallRules)
-		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+		if iface.Management && (iface.EgressRateLimit != "" || iface.IngressRateLimit != "") {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "egress_rate_limit", ValidationCodeInvalid, errors.New("rate limits can only be set on bridged interfaces")))
 		}
 
-		for addressIndex, address := range bond.Addresses {
-			err := validateAddressWithCIDR(address)
-			if err != nil {
-				return fmt.Errorf("bond %d address %d %s", index, addressIndex, err.Error())
-			}
+		if iface.EgressRateLimit != "" && !isValidBandwidth(iface.EgressRateLimit) {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "egress_rate_limit", ValidationCodeInvalid, fmt.Errorf("invalid egress rate limit '%s'", iface.EgressRateLimit)))
 		}
 
-		err = validateRequiredForOnline(bond.RequiredForOnline)
+		if iface.IngressRateLimit != "" && !isValidBandwidth(iface.IngressRateLimit) {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ingress_rate_limit", ValidationCodeInvalid, fmt.Errorf("invalid ingress rate limit '%s'", iface.IngressRateLimit)))
+		}
+
+		err = validateLinkDNS(iface.DNS)
 		if err != nil {
-			return fmt.Errorf("bond %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "dns", ValidationCodeInvalid, err))
 		}
 
-		for routeIndex, route := range bond.Routes {
-			err := validateAddressWithCIDR(route.To)
-			if err != nil {
-				return fmt.Errorf("bond %d route %d 'To' %s", index, routeIndex, err.Error())
-			}
+		err = validateNTPServers(iface.NTPServers)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ntp_servers", ValidationCodeInvalid, err))
+		}
 
-			err = validateAddress(route.Via)
-			if err != nil {
-				return fmt.Errorf("bond %d route %d 'Via' %s", index, routeIndex, err.Error())
-			}
+		if iface.IPv6OnlyMode && !slices.Contains(iface.Addresses, "dhcp4") {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ipv6_only_mode", ValidationCodeMissing, errors.New("IPv6-only mode requires a 'dhcp4' address")))
 		}
 
-		if bond.Hwaddr != "" {
-			err = validateHwaddr(bond.Hwaddr, requireValidMAC)
-			if err != nil {
-				return fmt.Errorf("bond %d %s", index, err.Error())
-			}
+		err = validateDHCPClass(iface.DHCPVendorClass, iface.DHCPUserClass, iface.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "dhcp_vendor_class", ValidationCodeInvalid, err))
 		}
 
-		if len(bond.Members) == 0 {
-			return fmt.Errorf("bond %d has no members", index)
+		if iface.DHCPUseRoutes != nil && !slices.Contains(iface.Addresses, "dhcp4") {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "dhcp_use_routes", ValidationCodeMissing, errors.New("DHCP routes handling requires a 'dhcp4' address")))
 		}
 
-		for memberIndex, member := range bond.Members {
-			err := validateHwaddr(member, requireValidMAC)
-			if err != nil {
-				return fmt.Errorf("bond %d member %d %s", index, memberIndex, err.Error())
-			}
+		err = validateNeighbors(iface.Neighbors)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "neighbors", ValidationCodeInvalid, err))
 		}
 
-		err = validateEthernet(bond.Ethernet)
+		err = validateBridgeFDB(iface.BridgeFDB, getBridgeVLANs(iface.Name, iface.VLANTags, vlans))
 		if err != nil {
-			return fmt.Errorf("bond %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "bridge_fdb", ValidationCodeInvalid, err))
 		}
-	}
 
-	return nil
-}
+		if iface.MACLock && len(iface.MACLockHwaddrs) == 0 {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "mac_lock_hwaddrs", ValidationCodeMissing, errors.New("MAC locking requires at least one allowed MAC address")))
+		}
 
-func validateVLANs(cfg *api.SystemNetworkConfig) error {
-	for index, vlan := range cfg.VLANs {
-		err := validateName(vlan.Name)
+		err = validateMACLockHwaddrs(iface.MACLock, iface.MACLockHwaddrs)
 		if err != nil {
-			return fmt.Errorf("vlan %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "mac_lock_hwaddrs", ValidationCodeInvalid, err))
 		}
 
-		err = validateParent(vlan.Parent, cfg.Interfaces, cfg.Bonds)
+		err = validateVRRP(iface.VRRP, iface.Addresses)
 		if err != nil {
-			return fmt.Errorf("vlan %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "vrrp", ValidationCodeInvalid, err))
 		}
 
-		if vlan.ID < 0 || vlan.ID > 4094 {
-			return fmt.Errorf("vlan %d ID %d out of range", index, vlan.ID)
+		err = validateUdevRules(iface.UdevRules)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "udev_rules", ValidationCodeInvalid, err))
 		}
 
-		err = validateMTU(vlan.MTU)
-		if err != nil {
-			return fmt.Errorf("vlan %d %s", index, err.Error())
+		if iface.HardwareTimestamping != "" && !slices.Contains([]string{"all", "ptp"}, iface.HardwareTimestamping) {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "hardware_timestamping", ValidationCodeInvalid, fmt.Errorf("invalid hardware timestamping mode '%s'", iface.HardwareTimestamping)))
 		}
 
-		err = validateRoles(vlan.Roles)
+		err = validateSysctls(iface.Sysctls)
 		if err != nil {
-			return fmt.Errorf("vlan %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "sysctls", ValidationCodeInvalid, err))
 		}
 
-		err = validateFirewall(vlan.FirewallRules)
+		err = validateAddressOptions(iface.AddressOptions, iface.Addresses)
 		if err != nil {
-			return fmt.Errorf("interface %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "address_options", ValidationCodeInvalid, err))
 		}
 
-		for addressIndex, address := range vlan.Addresses {
-			err := validateAddressWithCIDR(address)
-			if err != nil {
-				return fmt.Errorf("vlan %d address %d %s", index, addressIndex, err.Error())
-			}
+		err = validateDHCPFallbackAddress(iface.DHCPFallbackAddress, iface.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "dhcp_fallback_address", ValidationCodeInvalid, err))
 		}
 
-		err = validateRequiredForOnline(vlan.RequiredForOnline)
+		err = validateRouteMetric(iface.LinkType, iface.RouteMetric)
 		if err != nil {
-			return fmt.Errorf("vlan %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "route_metric", ValidationCodeInvalid, err))
 		}
 
-		for routeIndex, route := range vlan.Routes {
-			err := validateAddressWithCIDR(route.To)
-			if err != nil {
-				return fmt.Errorf("vlan %d route %d 'To' %s", index, routeIndex, err.Error())
-			}
-
-			err = validateAddress(route.Via)
-			if err != nil {
-				return fmt.Errorf("vlan %d route %d 'Via' %s", index, routeIndex, err.Error())
-			}
+		err = validateDisableIPv6(iface.DisableIPv6, iface.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "disable_ipv6", ValidationCodeInvalid, err))
 		}
-	}
 
-	return nil
-}
+		err = validateIPMasquerade(iface.IPMasquerade, iface.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ip_masquerade", ValidationCodeInvalid, err))
+		}
 
-func validateWireguard(cfg *api.SystemNetworkConfig) error {
-	for index, wg := range cfg.Wireguard {
-		err := validateName(wg.Name)
+		err = validateKeepConfiguration(iface.KeepConfiguration)
 		if err != nil {
-			return fmt.Errorf("wireguard %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "keep_configuration", ValidationCodeInvalid, err))
 		}
 
-		err = validateMTU(wg.MTU)
+		err = validateIPForwarding(iface.IPForwarding, iface.Addresses)
 		if err != nil {
-			return fmt.Errorf("wireguard %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ip_forwarding", ValidationCodeInvalid, err))
 		}
 
-		err = validateRoles(wg.Roles)
+		err = validateProxyNDPAddresses(iface.IPv6ProxyNDPAddresses)
 		if err != nil {
-			return fmt.Errorf("wireguard %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ipv6_proxy_ndp_addresses", ValidationCodeInvalid, err))
 		}
 
-		err = validateFirewall(wg.FirewallRules)
+		err = validateIPv6AddressGenerationMode(iface.IPv6AddressGenerationMode, iface.DisableIPv6)
 		if err != nil {
-			return fmt.Errorf("wireguard %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ipv6_address_generation_mode", ValidationCodeInvalid, err))
 		}
 
-		for addressIndex, address := range wg.Addresses {
-			err := validateAddressWithCIDR(address)
-			if err != nil {
-				return fmt.Errorf("wireguard %d address %d %s", index, addressIndex, err.Error())
-			}
+		if iface.IPv6DAD != nil && *iface.IPv6DAD < 0 {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "ipv6_dad", ValidationCodeOutOfRange, errors.New("IPv6 DAD transmit count can't be negative")))
 		}
 
-		err = validateRequiredForOnline(wg.RequiredForOnline)
+		err = validateFilePriority(interfaceFileOffset, iface.Priority)
 		if err != nil {
-			return fmt.Errorf("wireguard %d %s", index, err.Error())
+			errs = append(errs, newValidationError("interface", index, iface.Name, "priority", ValidationCodeOutOfRange, err))
 		}
 
-		if !isValidBase64(wg.PrivateKey) {
-			return fmt.Errorf("wireguard %d private key '%s' invalid", index, wg.PrivateKey)
+		err = validateCarrierDelay(iface.CarrierDelay)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "carrier_delay", ValidationCodeInvalid, err))
 		}
 
-		if wg.Port < 0 || wg.Port > 65535 {
-			return fmt.Errorf("wireguard %d port '%d' out of range", index, wg.Port)
+		err = validateBridgeVLAN(iface.DefaultPVID, iface.VLANProtocol, iface.VLANTags, iface.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "vlan_tags", ValidationCodeInvalid, err))
 		}
 
-		for routeIndex, route := range wg.Routes {
-			err := validateAddressWithCIDR(route.To)
-			if err != nil {
-				return fmt.Errorf("wireguard %d route %d 'To' %s", index, routeIndex, err.Error())
-			}
+		err = validateBridgePort(iface.BridgeCost, iface.BridgePriority)
+		if err != nil {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "bridge_cost", ValidationCodeOutOfRange, err))
+		}
 
-			err = validateAddress(route.Via)
+		if iface.VethPeerName != "" {
+			err = validateName(iface.VethPeerName)
 			if err != nil {
-				return fmt.Errorf("wireguard %d route %d 'Via' %s", index, routeIndex, err.Error())
+				errs = append(errs, newValidationError("interface", index, iface.Name, "veth_peer_name", ValidationCodeInvalid, err))
 			}
 		}
 
-		for peerIndex, peer := range wg.Peers {
-			if !isValidBase64(peer.PublicKey) {
-				return fmt.Errorf("wireguard %d peer %d public key invalid", index, peerIndex)
-			}
-
-			if peer.PresharedKey != "" && !isValidBase64(peer.PresharedKey) {
-				return fmt.Errorf("wireguard %d peer %d preshared key invalid", index, peerIndex)
-			}
+		if iface.VethPeerDetached && iface.MACLock {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "mac_lock", ValidationCodeInvalid, errors.New("MAC locking can't be used when the veth peer is detached")))
+		}
 
-			for addressIndex, address := range peer.AllowedIPs {
-				err := validateAddressWithCIDR(address)
-				if err != nil {
-					return fmt.Errorf("wireguard %d peer %d allowed IP %d %s", index, peerIndex, addressIndex, err.Error())
-				}
-			}
+		if iface.VethPeerDetached && slices.ContainsFunc(iface.BridgeFDB, func(e api.SystemNetworkBridgeFDB) bool { return e.Destination == "host" }) {
+			errs = append(errs, newValidationError("interface", index, iface.Name, "bridge_fdb", ValidationCodeInvalid, errors.New("bridge FDB entries can't point to the host when the veth peer is detached")))
+		}
 
-			if peer.PersistentKeepalive < 0 || peer.PersistentKeepalive > 65535 {
-				return fmt.Errorf("wireguard %d peer %d persitent keepalive %d out of range", index, peerIndex, peer.PersistentKeepalive)
+		if iface.Management {
+			err = validateManagement(iface, managementInterface)
+			if err != nil {
+				errs = append(errs, newValidationError("interface", index, iface.Name, "management", ValidationCodeInvalid, err))
 			}
 
-			if peer.Endpoint != "" {
-				_, _, err = net.SplitHostPort(peer.Endpoint)
-				if err != nil {
-					return fmt.Errorf("wireguard %d peer %d invalid endpoint '%s': %w", index, peerIndex, peer.Endpoint, err)
-				}
+			if managementInterface == "" {
+				managementInterface = iface.Name
 			}
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
-func validateName(name string) error {
-	if name == "" {
-		return errors.New("has no name")
-	}
-
-	if strings.HasPrefix(name, "_") {
-		return errors.New("name cannot begin with an underscore")
+// validateManagement checks that a management interface doesn't rely on any bridge feature
+// and that no other interface was already marked as the management one.
+func validateManagement(iface api.SystemNetworkInterface, existing string) error {
+	if existing != "" {
+		return fmt.Errorf("interface '%s' is already the management interface", existing)
 	}
 
-	if len(name) > 13 {
-		return errors.New("name '" + name + "' cannot be longer than 13 characters")
+	if iface.StrictHwaddr || len(iface.VLANTags) > 0 || iface.DefaultPVID != nil || iface.STP || iface.BridgeCost != 0 || iface.BridgePriority != nil || len(iface.BridgeFDB) > 0 || iface.VethPeerName != "" || iface.VethPeerDetached || iface.MACLock {
+		return errors.New("management interfaces aren't bridged and can't use strict_hwaddr, vlan_tags, default_pvid, stp, bridge_cost, bridge_priority, bridge_fdb, veth_peer_name, veth_peer_detached or mac_lock")
 	}
 
 	return nil
 }
 
-func validateMode(mode string) error {
-	if mode != "balance-rr" && mode != "active-backup" && mode != "balance-xor" && mode != "broadcast" && mode != "802.3ad" && mode != "balance-tlb" && mode != "balance-alb" {
-		return fmt.Errorf("invalid Mode value '%s'", mode)
-	}
+func validateBonds(bonds []api.SystemNetworkBond, vlans []api.SystemNetworkVLAN, requireValidMAC bool) error {
+	var errs []error
 
-	return nil
-}
+	for index, bond := range bonds {
+		err := validateName(bond.Name, "_b", "_v")
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "name", ValidationCodeInvalid, err))
+		}
 
-func validateParent(parent string, interfaces []api.SystemNetworkInterface, bonds []api.SystemNetworkBond) error {
-	if parent == "" {
-		return errors.New("has no parent")
-	}
+		err = validateExtraOptions(bond.ExtraOptions)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "extra_options", ValidationCodeInvalid, err))
+		}
 
-	foundParent := false
+		err = validateMode(bond.Mode)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "mode", ValidationCodeInvalid, err))
+		}
 
-	for _, i := range interfaces {
-		if i.Name == parent {
-			foundParent = true
+		err = validateMTU(bond.MTU)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "mtu", ValidationCodeOutOfRange, err))
+		}
 
-			break
+		err = validateRoles(bond.Roles)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "roles", ValidationCodeInvalid, err))
 		}
-	}
 
-	if !foundParent {
-		for _, b := range bonds {
-			if b.Name == parent {
-				foundParent = true
+		err = validateFirewall(bond.FirewallRules)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "firewall_rules", ValidationCodeInvalid, err))
+		}
 
-				break
+		for addressIndex, address := range bond.Addresses {
+			err := validateDeviceAddress(address)
+			if err != nil {
+				errs = append(errs, newValidationError("bond", index, bond.Name, "addresses", ValidationCodeInvalid, fmt.Errorf("address %d %s", addressIndex, err.Error())))
 			}
 		}
-	}
-
-	if !foundParent {
-		return fmt.Errorf("unable to find parent '%s'", parent)
-	}
-
-	return nil
-}
-
-func validateRoles(roles []string) error {
-	existing := make([]string, 0, len(roles))
 
-	for _, role := range roles {
-		// Confirm role is valid.
-		if !slices.Contains([]string{api.SystemNetworkInterfaceRoleManagement, api.SystemNetworkInterfaceRoleCluster, api.SystemNetworkInterfaceRoleInstances, api.SystemNetworkInterfaceRoleStorage}, role) {
-			return fmt.Errorf("role %q is unsupported", role)
+		err = validateLinkLocalOnly(bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "addresses", ValidationCodeInvalid, err))
 		}
 
-		// Duplicate detection.
-		if slices.Contains(existing, role) {
-			return fmt.Errorf("role %q is listed multiple times", role)
+		err = validateRequiredForOnline(bond.RequiredForOnline)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "required_for_online", ValidationCodeInvalid, err))
 		}
 
-		existing = append(existing, role)
-	}
+		for routeIndex, route := range bond.Routes {
+			err := validateAddressWithCIDR(route.To)
+			if err != nil {
+				errs = append(errs, newValidationError("bond", index, bond.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d 'To' %s", routeIndex, err.Error())))
+			}
 
-	return nil
-}
+			err = validateRouteVia(route, bond.Addresses)
+			if err != nil {
+				errs = append(errs, newValidationError("bond", index, bond.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
 
-func validateFirewall(rules []api.SystemNetworkFirewallRule) error {
-	for _, rule := range rules {
-		// Check the action.
-		if !slices.Contains([]string{"accept", "drop", "reject"}, rule.Action) {
-			return fmt.Errorf("unsupported action %q", rule.Action)
-		}
+			err = validateRouteSource(route, bond.Addresses)
+			if err != nil {
+				errs = append(errs, newValidationError("bond", index, bond.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
 
-		// Check the protocol.
-		if !slices.Contains([]string{"", "tcp", "udp"}, rule.Protocol) {
-			return fmt.Errorf("unsupported protocol %q", rule.Protocol)
+			err = validateRouteBFD(route)
+			if err != nil {
+				errs = append(errs, newValidationError("bond", index, bond.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+
+			err = validateRouteMTU(route, bond.MTU)
+			if err != nil {
+				errs = append(errs, newValidationError("bond", index, bond.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+		}
+
+		if bond.Hwaddr != "" {
+			err = validateHwaddr(bond.Hwaddr, requireValidMAC)
+			if err != nil {
+				errs = append(errs, newValidationError("bond", index, bond.Name, "hwaddr", ValidationCodeInvalid, err))
+			}
+		}
+
+		if len(bond.Members) == 0 {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "members", ValidationCodeMissing, errors.New("has no members")))
+		}
+
+		for memberIndex, member := range bond.Members {
+			err := validateHwaddr(member, requireValidMAC)
+			if err != nil {
+				errs = append(errs, newValidationError("bond", index, bond.Name, "members", ValidationCodeInvalid, fmt.Errorf("member %d %s", memberIndex, err.Error())))
+			}
+		}
+
+		err = validateEthernet(bond.Ethernet)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ethernet", ValidationCodeInvalid, err))
+		}
+
+		err = validateQoS(bond.QoS)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "qos", ValidationCodeInvalid, err))
+		}
+
+		if bond.EgressRateLimit != "" && !isValidBandwidth(bond.EgressRateLimit) {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "egress_rate_limit", ValidationCodeInvalid, fmt.Errorf("invalid egress rate limit '%s'", bond.EgressRateLimit)))
+		}
+
+		if bond.IngressRateLimit != "" && !isValidBandwidth(bond.IngressRateLimit) {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ingress_rate_limit", ValidationCodeInvalid, fmt.Errorf("invalid ingress rate limit '%s'", bond.IngressRateLimit)))
+		}
+
+		err = validateLinkDNS(bond.DNS)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "dns", ValidationCodeInvalid, err))
+		}
+
+		err = validateNTPServers(bond.NTPServers)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ntp_servers", ValidationCodeInvalid, err))
+		}
+
+		if bond.IPv6OnlyMode && !slices.Contains(bond.Addresses, "dhcp4") {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ipv6_only_mode", ValidationCodeMissing, errors.New("IPv6-only mode requires a 'dhcp4' address")))
+		}
+
+		err = validateDHCPClass(bond.DHCPVendorClass, bond.DHCPUserClass, bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "dhcp_vendor_class", ValidationCodeInvalid, err))
+		}
+
+		if bond.DHCPUseRoutes != nil && !slices.Contains(bond.Addresses, "dhcp4") {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "dhcp_use_routes", ValidationCodeMissing, errors.New("DHCP routes handling requires a 'dhcp4' address")))
+		}
+
+		err = validateNeighbors(bond.Neighbors)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "neighbors", ValidationCodeInvalid, err))
+		}
+
+		err = validateBridgeFDB(bond.BridgeFDB, getBridgeVLANs(bond.Name, bond.VLANTags, vlans))
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "bridge_fdb", ValidationCodeInvalid, err))
+		}
+
+		if bond.MACLock && len(bond.MACLockHwaddrs) == 0 {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "mac_lock_hwaddrs", ValidationCodeMissing, errors.New("MAC locking requires at least one allowed MAC address")))
+		}
+
+		err = validateMACLockHwaddrs(bond.MACLock, bond.MACLockHwaddrs)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "mac_lock_hwaddrs", ValidationCodeInvalid, err))
+		}
+
+		err = validateVRRP(bond.VRRP, bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "vrrp", ValidationCodeInvalid, err))
+		}
+
+		err = validateSysctls(bond.Sysctls)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "sysctls", ValidationCodeInvalid, err))
+		}
+
+		err = validateAddressOptions(bond.AddressOptions, bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "address_options", ValidationCodeInvalid, err))
+		}
+
+		err = validateDHCPFallbackAddress(bond.DHCPFallbackAddress, bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "dhcp_fallback_address", ValidationCodeInvalid, err))
+		}
+
+		err = validateRouteMetric(bond.LinkType, bond.RouteMetric)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "route_metric", ValidationCodeInvalid, err))
+		}
+
+		err = validateDisableIPv6(bond.DisableIPv6, bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "disable_ipv6", ValidationCodeInvalid, err))
+		}
+
+		err = validateIPMasquerade(bond.IPMasquerade, bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ip_masquerade", ValidationCodeInvalid, err))
+		}
+
+		err = validateKeepConfiguration(bond.KeepConfiguration)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "keep_configuration", ValidationCodeInvalid, err))
+		}
+
+		err = validateIPForwarding(bond.IPForwarding, bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ip_forwarding", ValidationCodeInvalid, err))
+		}
+
+		err = validateProxyNDPAddresses(bond.IPv6ProxyNDPAddresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ipv6_proxy_ndp_addresses", ValidationCodeInvalid, err))
+		}
+
+		err = validateIPv6AddressGenerationMode(bond.IPv6AddressGenerationMode, bond.DisableIPv6)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ipv6_address_generation_mode", ValidationCodeInvalid, err))
+		}
+
+		if bond.IPv6DAD != nil && *bond.IPv6DAD < 0 {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ipv6_dad", ValidationCodeOutOfRange, errors.New("IPv6 DAD transmit count can't be negative")))
+		}
+
+		err = validateFilePriority(bondFileOffset, bond.Priority)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "priority", ValidationCodeOutOfRange, err))
+		}
+
+		err = validateCarrierDelay(bond.CarrierDelay)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "carrier_delay", ValidationCodeInvalid, err))
+		}
+
+		if bond.MinLinks < 0 || bond.MinLinks > len(bond.Members) {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "min_links", ValidationCodeOutOfRange, fmt.Errorf("min links %d out of range (0 to %d)", bond.MinLinks, len(bond.Members))))
+		} else if bond.MinLinks > 0 && bond.Mode != "802.3ad" {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "min_links", ValidationCodeInvalid, errors.New("min links can only be used with 802.3ad mode")))
+		}
+
+		err = validateBondActor(bond)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "ad_actor_system", ValidationCodeInvalid, err))
+		}
+
+		err = validateBridgeVLAN(bond.DefaultPVID, bond.VLANProtocol, bond.VLANTags, bond.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "vlan_tags", ValidationCodeInvalid, err))
+		}
+
+		err = validateBridgePort(bond.BridgeCost, bond.BridgePriority)
+		if err != nil {
+			errs = append(errs, newValidationError("bond", index, bond.Name, "bridge_cost", ValidationCodeOutOfRange, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateTeams(cfg *api.SystemNetworkConfig, requireValidMAC bool) error {
+	teamMembers := []string{}
+
+	for index, team := range cfg.Teams {
+		err := validateName(team.Name, "_a", "_v")
+		if err != nil {
+			return fmt.Errorf("team %d %s", index, err.Error())
+		}
+
+		err = validateTeamMode(team.Mode)
+		if err != nil {
+			return fmt.Errorf("team %d %s", index, err.Error())
+		}
+
+		err = validateMTU(team.MTU)
+		if err != nil {
+			return fmt.Errorf("team %d %s", index, err.Error())
+		}
+
+		err = validateRoles(team.Roles)
+		if err != nil {
+			return fmt.Errorf("team %d %s", index, err.Error())
+		}
+
+		err = validateFirewall(team.FirewallRules)
+		if err != nil {
+			return fmt.Errorf("team %d %s", index, err.Error())
+		}
+
+		for addressIndex, address := range team.Addresses {
+			err := validateAddressWithCIDR(address)
+			if err != nil {
+				return fmt.Errorf("team %d address %d %s", index, addressIndex, err.Error())
+			}
+		}
+
+		err = validateRequiredForOnline(team.RequiredForOnline)
+		if err != nil {
+			return fmt.Errorf("team %d %s", index, err.Error())
+		}
+
+		err = validateRouteMetric(team.LinkType, team.RouteMetric)
+		if err != nil {
+			return fmt.Errorf("team %d %s", index, err.Error())
+		}
+
+		err = validateKeepConfiguration(team.KeepConfiguration)
+		if err != nil {
+			return fmt.Errorf("team %d %s", index, err.Error())
+		}
+
+		for routeIndex, route := range team.Routes {
+			err := validateAddressWithCIDR(route.To)
+			if err != nil {
+				return fmt.Errorf("team %d route %d 'To' %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteVia(route, team.Addresses)
+			if err != nil {
+				return fmt.Errorf("team %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteSource(route, team.Addresses)
+			if err != nil {
+				return fmt.Errorf("team %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteBFD(route)
+			if err != nil {
+				return fmt.Errorf("team %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteMTU(route, team.MTU)
+			if err != nil {
+				return fmt.Errorf("team %d route %d %s", index, routeIndex, err.Error())
+			}
+		}
+
+		if team.Hwaddr != "" {
+			err = validateHwaddr(team.Hwaddr, requireValidMAC)
+			if err != nil {
+				return fmt.Errorf("team %d %s", index, err.Error())
+			}
+		}
+
+		if len(team.Members) == 0 {
+			return fmt.Errorf("team %d has no members", index)
+		}
+
+		for memberIndex, member := range team.Members {
+			err := validateHwaddr(member, requireValidMAC)
+			if err != nil {
+				return fmt.Errorf("team %d member %d %s", index, memberIndex, err.Error())
+			}
+
+			// A NIC can only be driven by one of the kernel bonding driver or teamd.
+			for _, bond := range cfg.Bonds {
+				if slices.ContainsFunc(bond.Members, func(m string) bool { return strings.EqualFold(m, member) }) {
+					return fmt.Errorf("team %d member %d is already a member of bond '%s'", index, memberIndex, bond.Name)
+				}
+			}
+
+			for _, iface := range cfg.Interfaces {
+				if strings.EqualFold(iface.Hwaddr, member) {
+					return fmt.Errorf("team %d member %d is already used by interface '%s'", index, memberIndex, iface.Name)
+				}
+			}
+
+			if slices.Contains(teamMembers, strings.ToLower(member)) {
+				return errors.New("duplicate MAC address: " + member)
+			}
+
+			teamMembers = append(teamMembers, strings.ToLower(member))
+		}
+	}
+
+	return nil
+}
+
+func validateVLANs(cfg *api.SystemNetworkConfig) error {
+	var errs []error
+
+	for index, vlan := range cfg.VLANs {
+		err := validateName(vlan.Name)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "name", ValidationCodeInvalid, err))
+		}
+
+		err = validateExtraOptions(vlan.ExtraOptions)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "extra_options", ValidationCodeInvalid, err))
+		}
+
+		err = validateParent(vlan.Parent, cfg.Interfaces, cfg.Bonds, cfg.Teams)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "parent", ValidationCodeInvalid, err))
+		}
+
+		if vlan.Hwaddr != "" {
+			err = validateHwaddr(vlan.Hwaddr, true)
+			if err != nil {
+				errs = append(errs, newValidationError("vlan", index, vlan.Name, "hwaddr", ValidationCodeInvalid, err))
+			}
+		}
+
+		if vlan.ID < 0 || vlan.ID > 4094 {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "id", ValidationCodeOutOfRange, fmt.Errorf("ID %d out of range", vlan.ID)))
+		}
+
+		err = validateMTU(vlan.MTU)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "mtu", ValidationCodeOutOfRange, err))
+		}
+
+		err = validateRoles(vlan.Roles)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "roles", ValidationCodeInvalid, err))
+		}
+
+		err = validateFirewall(vlan.FirewallRules)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "firewall_rules", ValidationCodeInvalid, err))
+		}
+
+		for addressIndex, address := range vlan.Addresses {
+			err := validateDeviceAddress(address)
+			if err != nil {
+				errs = append(errs, newValidationError("vlan", index, vlan.Name, "addresses", ValidationCodeInvalid, fmt.Errorf("address %d %s", addressIndex, err.Error())))
+			}
+		}
+
+		err = validateLinkLocalOnly(vlan.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "addresses", ValidationCodeInvalid, err))
+		}
+
+		err = validateRequiredForOnline(vlan.RequiredForOnline)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "required_for_online", ValidationCodeInvalid, err))
+		}
+
+		err = validateQoS(vlan.QoS)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "qos", ValidationCodeInvalid, err))
+		}
+
+		err = validateLinkDNS(vlan.DNS)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "dns", ValidationCodeInvalid, err))
+		}
+
+		err = validateNTPServers(vlan.NTPServers)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "ntp_servers", ValidationCodeInvalid, err))
+		}
+
+		if vlan.IPv6OnlyMode && !slices.Contains(vlan.Addresses, "dhcp4") {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "ipv6_only_mode", ValidationCodeMissing, errors.New("IPv6-only mode requires a 'dhcp4' address")))
+		}
+
+		err = validateDHCPClass(vlan.DHCPVendorClass, vlan.DHCPUserClass, vlan.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "dhcp_vendor_class", ValidationCodeInvalid, err))
+		}
+
+		if vlan.DHCPUseRoutes != nil && !slices.Contains(vlan.Addresses, "dhcp4") {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "dhcp_use_routes", ValidationCodeMissing, errors.New("DHCP routes handling requires a 'dhcp4' address")))
+		}
+
+		err = validateNeighbors(vlan.Neighbors)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "neighbors", ValidationCodeInvalid, err))
+		}
+
+		err = validateVRRP(vlan.VRRP, vlan.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "vrrp", ValidationCodeInvalid, err))
+		}
+
+		err = validateSysctls(vlan.Sysctls)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "sysctls", ValidationCodeInvalid, err))
+		}
+
+		err = validateAddressOptions(vlan.AddressOptions, vlan.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "address_options", ValidationCodeInvalid, err))
+		}
+
+		err = validateDHCPFallbackAddress(vlan.DHCPFallbackAddress, vlan.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "dhcp_fallback_address", ValidationCodeInvalid, err))
+		}
+
+		err = validateRouteMetric(vlan.LinkType, vlan.RouteMetric)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "route_metric", ValidationCodeInvalid, err))
+		}
+
+		err = validateDisableIPv6(vlan.DisableIPv6, vlan.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "disable_ipv6", ValidationCodeInvalid, err))
+		}
+
+		err = validateIPMasquerade(vlan.IPMasquerade, vlan.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "ip_masquerade", ValidationCodeInvalid, err))
+		}
+
+		err = validateKeepConfiguration(vlan.KeepConfiguration)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "keep_configuration", ValidationCodeInvalid, err))
+		}
+
+		err = validateIPForwarding(vlan.IPForwarding, vlan.Addresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "ip_forwarding", ValidationCodeInvalid, err))
+		}
+
+		err = validateProxyNDPAddresses(vlan.IPv6ProxyNDPAddresses)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "ipv6_proxy_ndp_addresses", ValidationCodeInvalid, err))
+		}
+
+		err = validateIPv6AddressGenerationMode(vlan.IPv6AddressGenerationMode, vlan.DisableIPv6)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "ipv6_address_generation_mode", ValidationCodeInvalid, err))
+		}
+
+		if vlan.IPv6DAD != nil && *vlan.IPv6DAD < 0 {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "ipv6_dad", ValidationCodeOutOfRange, errors.New("IPv6 DAD transmit count can't be negative")))
+		}
+
+		err = validateFilePriority(vlanFileOffset, vlan.Priority)
+		if err != nil {
+			errs = append(errs, newValidationError("vlan", index, vlan.Name, "priority", ValidationCodeOutOfRange, err))
+		}
+
+		for routeIndex, route := range vlan.Routes {
+			err := validateAddressWithCIDR(route.To)
+			if err != nil {
+				errs = append(errs, newValidationError("vlan", index, vlan.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d 'To' %s", routeIndex, err.Error())))
+			}
+
+			err = validateRouteVia(route, vlan.Addresses)
+			if err != nil {
+				errs = append(errs, newValidationError("vlan", index, vlan.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+
+			err = validateRouteSource(route, vlan.Addresses)
+			if err != nil {
+				errs = append(errs, newValidationError("vlan", index, vlan.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+
+			err = validateRouteBFD(route)
+			if err != nil {
+				errs = append(errs, newValidationError("vlan", index, vlan.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+
+			err = validateRouteMTU(route, vlan.MTU)
+			if err != nil {
+				errs = append(errs, newValidationError("vlan", index, vlan.Name, "routes", ValidationCodeInvalid, fmt.Errorf("route %d %s", routeIndex, err.Error())))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateWireguard(cfg *api.SystemNetworkConfig) error {
+	for index, wg := range cfg.Wireguard {
+		err := validateName(wg.Name)
+		if err != nil {
+			return fmt.Errorf("wireguard %d %s", index, err.Error())
+		}
+
+		err = validateExtraOptions(wg.ExtraOptions)
+		if err != nil {
+			return fmt.Errorf("wireguard %d %s", index, err.Error())
+		}
+
+		err = validateMTU(wg.MTU)
+		if err != nil {
+			return fmt.Errorf("wireguard %d %s", index, err.Error())
+		}
+
+		err = validateRoles(wg.Roles)
+		if err != nil {
+			return fmt.Errorf("wireguard %d %s", index, err.Error())
+		}
+
+		err = validateFirewall(wg.FirewallRules)
+		if err != nil {
+			return fmt.Errorf("wireguard %d %s", index, err.Error())
+		}
+
+		for addressIndex, address := range wg.Addresses {
+			err := validateAddressWithCIDR(address)
+			if err != nil {
+				return fmt.Errorf("wireguard %d address %d %s", index, addressIndex, err.Error())
+			}
+		}
+
+		err = validateRequiredForOnline(wg.RequiredForOnline)
+		if err != nil {
+			return fmt.Errorf("wireguard %d %s", index, err.Error())
+		}
+
+		if !isValidBase64(wg.PrivateKey) {
+			return fmt.Errorf("wireguard %d private key '%s' invalid", index, wg.PrivateKey)
+		}
+
+		if wg.Port < 0 || wg.Port > 65535 {
+			return fmt.Errorf("wireguard %d port '%d' out of range", index, wg.Port)
+		}
+
+		for routeIndex, route := range wg.Routes {
+			err := validateAddressWithCIDR(route.To)
+			if err != nil {
+				return fmt.Errorf("wireguard %d route %d 'To' %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteVia(route, wg.Addresses)
+			if err != nil {
+				return fmt.Errorf("wireguard %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteSource(route, wg.Addresses)
+			if err != nil {
+				return fmt.Errorf("wireguard %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteBFD(route)
+			if err != nil {
+				return fmt.Errorf("wireguard %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteMTU(route, wg.MTU)
+			if err != nil {
+				return fmt.Errorf("wireguard %d route %d %s", index, routeIndex, err.Error())
+			}
+		}
+
+		for peerIndex, peer := range wg.Peers {
+			if !isValidBase64(peer.PublicKey) {
+				return fmt.Errorf("wireguard %d peer %d public key invalid", index, peerIndex)
+			}
+
+			if peer.PresharedKey != "" && !isValidBase64(peer.PresharedKey) {
+				return fmt.Errorf("wireguard %d peer %d preshared key invalid", index, peerIndex)
+			}
+
+			for addressIndex, address := range peer.AllowedIPs {
+				err := validateAddressWithCIDR(address)
+				if err != nil {
+					return fmt.Errorf("wireguard %d peer %d allowed IP %d %s", index, peerIndex, addressIndex, err.Error())
+				}
+			}
+
+			if peer.PersistentKeepalive < 0 || peer.PersistentKeepalive > 65535 {
+				return fmt.Errorf("wireguard %d peer %d persitent keepalive %d out of range", index, peerIndex, peer.PersistentKeepalive)
+			}
+
+			if peer.Endpoint != "" {
+				_, _, err = net.SplitHostPort(peer.Endpoint)
+				if err != nil {
+					return fmt.Errorf("wireguard %d peer %d invalid endpoint '%s': %w", index, peerIndex, peer.Endpoint, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateTunnels(cfg *api.SystemNetworkConfig) error {
+	for index, tunnel := range cfg.Tunnels {
+		err := validateName(tunnel.Name, "_t", "_v")
+		if err != nil {
+			return fmt.Errorf("tunnel %d %s", index, err.Error())
+		}
+
+		err = validateExtraOptions(tunnel.ExtraOptions)
+		if err != nil {
+			return fmt.Errorf("tunnel %d %s", index, err.Error())
+		}
+
+		if !slices.Contains([]string{"gre", "gretap", "ip6gre", "sit"}, tunnel.Kind) {
+			return fmt.Errorf("tunnel %d invalid kind '%s'", index, tunnel.Kind)
+		}
+
+		err = validateMTU(tunnel.MTU)
+		if err != nil {
+			return fmt.Errorf("tunnel %d %s", index, err.Error())
+		}
+
+		err = validateRoles(tunnel.Roles)
+		if err != nil {
+			return fmt.Errorf("tunnel %d %s", index, err.Error())
+		}
+
+		err = validateFirewall(tunnel.FirewallRules)
+		if err != nil {
+			return fmt.Errorf("tunnel %d %s", index, err.Error())
+		}
+
+		if tunnel.Remote == "" {
+			return fmt.Errorf("tunnel %d has no remote endpoint", index)
+		}
+
+		// Endpoints must match the address family of the tunnel.
+		wantIPv6 := tunnel.Kind == "ip6gre"
+
+		for _, endpoint := range []string{tunnel.Remote, tunnel.Local} {
+			if endpoint == "" {
+				continue
+			}
+
+			ip := net.ParseIP(endpoint)
+			if ip == nil {
+				return fmt.Errorf("tunnel %d invalid endpoint '%s'", index, endpoint)
+			}
+
+			if (ip.To4() == nil) != wantIPv6 {
+				return fmt.Errorf("tunnel %d endpoint '%s' doesn't match the address family of a %s tunnel", index, endpoint, tunnel.Kind)
+			}
+		}
+
+		if tunnel.Key < 0 || tunnel.Key > 4294967295 {
+			return fmt.Errorf("tunnel %d key '%d' out of range", index, tunnel.Key)
+		}
+
+		if tunnel.Key != 0 && tunnel.Kind == "sit" {
+			return fmt.Errorf("tunnel %d key isn't supported for sit tunnels", index)
+		}
+
+		if tunnel.TTL < 0 || tunnel.TTL > 255 {
+			return fmt.Errorf("tunnel %d TTL '%d' out of range", index, tunnel.TTL)
+		}
+
+		for addressIndex, address := range tunnel.Addresses {
+			err := validateAddressWithCIDR(address)
+			if err != nil {
+				return fmt.Errorf("tunnel %d address %d %s", index, addressIndex, err.Error())
+			}
+		}
+
+		err = validateRequiredForOnline(tunnel.RequiredForOnline)
+		if err != nil {
+			return fmt.Errorf("tunnel %d %s", index, err.Error())
+		}
+
+		for routeIndex, route := range tunnel.Routes {
+			err := validateAddressWithCIDR(route.To)
+			if err != nil {
+				return fmt.Errorf("tunnel %d route %d 'To' %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteVia(route, tunnel.Addresses)
+			if err != nil {
+				return fmt.Errorf("tunnel %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteSource(route, tunnel.Addresses)
+			if err != nil {
+				return fmt.Errorf("tunnel %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteBFD(route)
+			if err != nil {
+				return fmt.Errorf("tunnel %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteMTU(route, tunnel.MTU)
+			if err != nil {
+				return fmt.Errorf("tunnel %d route %d %s", index, routeIndex, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateIPVLANs(cfg *api.SystemNetworkConfig) error {
+	for index, ipvlan := range cfg.IPVLANs {
+		err := validateName(ipvlan.Name)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		err = validateExtraOptions(ipvlan.ExtraOptions)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		err = validateParent(ipvlan.Parent, cfg.Interfaces, cfg.Bonds, cfg.Teams)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		if !slices.Contains([]string{"l2", "l3", "l3s"}, ipvlan.Mode) {
+			return fmt.Errorf("ipvlan %d invalid mode '%s'", index, ipvlan.Mode)
+		}
+
+		err = validateMTU(ipvlan.MTU)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		err = validateRoles(ipvlan.Roles)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		err = validateFirewall(ipvlan.FirewallRules)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		for addressIndex, address := range ipvlan.Addresses {
+			err := validateAddressWithCIDR(address)
+			if err != nil {
+				return fmt.Errorf("ipvlan %d address %d %s", index, addressIndex, err.Error())
+			}
+		}
+
+		err = validateRequiredForOnline(ipvlan.RequiredForOnline)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		err = validateRouteMetric(ipvlan.LinkType, ipvlan.RouteMetric)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		err = validateKeepConfiguration(ipvlan.KeepConfiguration)
+		if err != nil {
+			return fmt.Errorf("ipvlan %d %s", index, err.Error())
+		}
+
+		for routeIndex, route := range ipvlan.Routes {
+			err := validateAddressWithCIDR(route.To)
+			if err != nil {
+				return fmt.Errorf("ipvlan %d route %d 'To' %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteVia(route, ipvlan.Addresses)
+			if err != nil {
+				return fmt.Errorf("ipvlan %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteSource(route, ipvlan.Addresses)
+			if err != nil {
+				return fmt.Errorf("ipvlan %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteBFD(route)
+			if err != nil {
+				return fmt.Errorf("ipvlan %d route %d %s", index, routeIndex, err.Error())
+			}
+
+			err = validateRouteMTU(route, ipvlan.MTU)
+			if err != nil {
+				return fmt.Errorf("ipvlan %d route %d %s", index, routeIndex, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateVRFs(cfg *api.SystemNetworkConfig) error {
+	tables := map[int]string{}
+
+	for index, vrf := range cfg.VRFs {
+		err := validateName(vrf.Name)
+		if err != nil {
+			return fmt.Errorf("vrf %d %s", index, err.Error())
+		}
+
+		// Tables 253, 254 and 255 are reserved for the default, main and local tables.
+		if vrf.Table < 1 || vrf.Table > 4294967295 || (vrf.Table >= 253 && vrf.Table <= 255) {
+			return fmt.Errorf("vrf %d table %d out of range", index, vrf.Table)
+		}
+
+		_, exists := tables[vrf.Table]
+		if exists {
+			return fmt.Errorf("vrf %d table %d already used by vrf '%s'", index, vrf.Table, tables[vrf.Table])
+		}
+
+		tables[vrf.Table] = vrf.Name
+	}
+
+	// Check that devices reference an existing VRF and that their routes don't target another table.
+	checkVRF := func(vrfName string, routes []api.SystemNetworkRoute) error {
+		if vrfName == "" {
+			return nil
+		}
+
+		vrfIndex := slices.IndexFunc(cfg.VRFs, func(v api.SystemNetworkVRF) bool {
+			return v.Name == vrfName
+		})
+
+		if vrfIndex < 0 {
+			return fmt.Errorf("vrf '%s' doesn't exist", vrfName)
+		}
+
+		for routeIndex, route := range routes {
+			if route.Table != 0 && route.Table != cfg.VRFs[vrfIndex].Table {
+				return fmt.Errorf("route %d table %d conflicts with vrf '%s' table %d", routeIndex, route.Table, vrfName, cfg.VRFs[vrfIndex].Table)
+			}
+
+			if route.BFD != nil {
+				return fmt.Errorf("route %d BFD isn't supported on devices in a vrf", routeIndex)
+			}
+		}
+
+		return nil
+	}
+
+	for index, iface := range cfg.Interfaces {
+		err := checkVRF(iface.VRF, iface.Routes)
+		if err != nil {
+			return newValidationError("interface", index, iface.Name, "vrf", ValidationCodeInvalid, err)
+		}
+	}
+
+	for index, bond := range cfg.Bonds {
+		err := checkVRF(bond.VRF, bond.Routes)
+		if err != nil {
+			return newValidationError("bond", index, bond.Name, "vrf", ValidationCodeInvalid, err)
+		}
+	}
+
+	for index, vlan := range cfg.VLANs {
+		err := checkVRF(vlan.VRF, vlan.Routes)
+		if err != nil {
+			return newValidationError("vlan", index, vlan.Name, "vrf", ValidationCodeInvalid, err)
+		}
+	}
+
+	return nil
+}
+
+// maxDeviceNameLength is the longest network device name accepted by the kernel (IFNAMSIZ - 1).
+const maxDeviceNameLength = 15
+
+// validateDefaultRouteSource checks that at most one device is the default route source.
+func validateDefaultRouteSource(cfg *api.SystemNetworkConfig) error {
+	sources := []string{}
+
+	for _, i := range cfg.Interfaces {
+		if i.DefaultRouteSource {
+			sources = append(sources, i.Name)
+		}
+	}
+
+	for _, b := range cfg.Bonds {
+		if b.DefaultRouteSource {
+			sources = append(sources, b.Name)
+		}
+	}
+
+	for _, v := range cfg.VLANs {
+		if v.DefaultRouteSource {
+			sources = append(sources, v.Name)
+		}
+	}
+
+	for _, v := range cfg.IPVLANs {
+		if v.DefaultRouteSource {
+			sources = append(sources, v.Name)
+		}
+	}
+
+	if len(sources) > 1 {
+		return fmt.Errorf("only one device can be the default route source, got '%s'", strings.Join(sources, "', '"))
+	}
+
+	return nil
+}
+
+// validatePreferredBridge checks that the preferred bridge is one of the generated bridges.
+func validatePreferredBridge(cfg *api.SystemNetworkConfig) error {
+	if cfg.PreferredBridge == "" {
+		return nil
+	}
+
+	bridges := []string{}
+
+	for _, i := range cfg.Interfaces {
+		if !i.Management {
+			bridges = append(bridges, i.Name)
+		}
+	}
+
+	for _, b := range cfg.Bonds {
+		bridges = append(bridges, b.Name)
+	}
+
+	for _, t := range cfg.Teams {
+		bridges = append(bridges, t.Name)
+	}
+
+	for _, t := range cfg.Tunnels {
+		if t.Kind == "gretap" {
+			bridges = append(bridges, t.Name)
+		}
+	}
+
+	if !slices.Contains(bridges, cfg.PreferredBridge) {
+		return fmt.Errorf("preferred bridge '%s' isn't a bridged interface, bond, team or tunnel", cfg.PreferredBridge)
+	}
+
+	return nil
+}
+
+// validateOnlineGroups checks that online groups only reference existing devices, each in at most one group.
+func validateOnlineGroups(cfg *api.SystemNetworkConfig) error {
+	devices := []string{}
+
+	for _, i := range cfg.Interfaces {
+		devices = append(devices, i.Name)
+	}
+
+	for _, b := range cfg.Bonds {
+		devices = append(devices, b.Name)
+	}
+
+	for _, t := range cfg.Teams {
+		devices = append(devices, t.Name)
+	}
+
+	for _, v := range cfg.VLANs {
+		devices = append(devices, v.Name)
+	}
+
+	for _, v := range cfg.IPVLANs {
+		devices = append(devices, v.Name)
+	}
+
+	groupNames := []string{}
+	memberGroups := map[string]string{}
+
+	for index, group := range cfg.OnlineGroups {
+		if group.Name == "" {
+			return fmt.Errorf("online group %d has no name", index)
+		}
+
+		if slices.Contains(groupNames, group.Name) {
+			return fmt.Errorf("duplicate online group name '%s'", group.Name)
+		}
+
+		groupNames = append(groupNames, group.Name)
+
+		if len(group.Members) == 0 {
+			return fmt.Errorf("online group '%s' has no members", group.Name)
+		}
+
+		for _, member := range group.Members {
+			if !slices.Contains(devices, member) {
+				return fmt.Errorf("online group '%s' member '%s' isn't an interface, bond, team, VLAN or IPVLAN", group.Name, member)
+			}
+
+			other, ok := memberGroups[member]
+			if ok {
+				return fmt.Errorf("online group '%s' member '%s' is already a member of online group '%s'", group.Name, member, other)
+			}
+
+			memberGroups[member] = group.Name
+		}
+	}
+
+	return nil
+}
+
+// validateIGMPProxy checks that the IGMP proxy has exactly one upstream device and at least one downstream device,
+// each being a distinct interface, bond or VLAN.
+func validateIGMPProxy(cfg *api.SystemNetworkConfig) error {
+	if cfg.IGMPProxy == nil || len(cfg.IGMPProxy.Interfaces) == 0 {
+		return nil
+	}
+
+	devices := getIGMPProxyDeviceNames(cfg)
+	seen := []string{}
+	numUpstreams := 0
+	numDownstreams := 0
+
+	// Multicast is forwarded like any other IPv4 traffic, so must be allowed through the forward filter.
+	ipForwarding := map[string]string{}
+
+	for _, i := range cfg.Interfaces {
+		ipForwarding[i.Name] = i.IPForwarding
+	}
+
+	for _, b := range cfg.Bonds {
+		ipForwarding[b.Name] = b.IPForwarding
+	}
+
+	for _, v := range cfg.VLANs {
+		ipForwarding[v.Name] = v.IPForwarding
+	}
+
+	for index, iface := range cfg.IGMPProxy.Interfaces {
+		_, ok := devices[iface.Name]
+		if !ok {
+			return fmt.Errorf("IGMP proxy interface %d '%s' isn't an interface, bond or VLAN", index, iface.Name)
+		}
+
+		if slices.Contains(seen, iface.Name) {
+			return fmt.Errorf("IGMP proxy interface '%s' is listed more than once", iface.Name)
+		}
+
+		seen = append(seen, iface.Name)
+
+		if ipForwarding[iface.Name] != "ipv4" && ipForwarding[iface.Name] != "both" {
+			return fmt.Errorf("IGMP proxy interface '%s' requires ip_forwarding to be 'ipv4' or 'both'", iface.Name)
+		}
+
+		switch iface.Role {
+		case "upstream":
+			numUpstreams++
+		case "downstream":
+			numDownstreams++
+		default:
+			return fmt.Errorf("IGMP proxy interface '%s' has invalid role '%s'", iface.Name, iface.Role)
+		}
+
+		for _, altnet := range iface.AltNets {
+			ip, _, err := net.ParseCIDR(altnet)
+			if err != nil || ip.To4() == nil {
+				return fmt.Errorf("IGMP proxy interface '%s' has invalid IPv4 altnet '%s'", iface.Name, altnet)
+			}
+		}
+	}
+
+	if numUpstreams != 1 {
+		return fmt.Errorf("IGMP proxy requires exactly one upstream interface, got %d", numUpstreams)
+	}
+
+	if numDownstreams == 0 {
+		return errors.New("IGMP proxy requires at least one downstream interface")
+	}
+
+	return nil
+}
+
+// validateRemoteSource checks that the remote source uses an HTTPS URL and valid certificates.
+func validateRemoteSource(source *api.SystemNetworkRemoteSource) error {
+	if source == nil {
+		return nil
+	}
+
+	_, err := getRemoteSourceClient(*source)
+
+	return err
+}
+
+// validateWebhook checks that the webhook uses an HTTPS URL and has a signing secret.
+func validateWebhook(webhook *api.SystemNetworkWebhook) error {
+	if webhook == nil {
+		return nil
+	}
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL '%s'", webhook.URL)
+	}
+
+	if u.Scheme != "https" {
+		return fmt.Errorf("webhook URL '%s' must use https", webhook.URL)
+	}
+
+	if webhook.Secret == "" {
+		return fmt.Errorf("webhook '%s' requires a signing secret", webhook.URL)
+	}
+
+	return nil
+}
+
+// validatePrefixDelegation checks the DHCPv6 prefix delegation settings of each device.
+func validatePrefixDelegation(cfg *api.SystemNetworkConfig) error {
+	numUplinks := 0
+	numDownstreams := 0
+
+	checkPrefixDelegation := func(pd *api.SystemNetworkPrefixDelegation, addresses []string) error {
+		if pd == nil {
+			return nil
+		}
+
+		switch pd.Mode {
+		case "uplink":
+			numUplinks++
+
+			if !slices.Contains(addresses, "dhcp6") {
+				return errors.New("prefix delegation uplink requires a 'dhcp6' address")
+			}
+
+			if pd.PrefixLength != 0 && (pd.PrefixLength < 1 || pd.PrefixLength > 64) {
+				return fmt.Errorf("prefix delegation prefix length %d out of range", pd.PrefixLength)
+			}
+
+			if pd.SubnetID != nil {
+				return errors.New("prefix delegation subnet ID can only be set on downstream devices")
+			}
+
+			if len(pd.AdvertisedRoutes) > 0 || pd.RouterPreference != "" || pd.HopLimit != 0 {
+				return errors.New("prefix delegation router advertisement settings can only be set on downstream devices")
+			}
+		case "downstream":
+			numDownstreams++
+
+			if pd.PrefixLength != 0 {
+				return errors.New("prefix delegation prefix length can only be set on the uplink")
+			}
+
+			if pd.SubnetID != nil && *pd.SubnetID < 0 {
+				return fmt.Errorf("prefix delegation subnet ID %d out of range", *pd.SubnetID)
+			}
+
+			if pd.RouterPreference != "" && !slices.Contains([]string{"high", "medium", "low"}, pd.RouterPreference) {
+				return fmt.Errorf("invalid prefix delegation router preference '%s'", pd.RouterPreference)
+			}
+
+			if pd.HopLimit < 0 || pd.HopLimit > 255 {
+				return fmt.Errorf("prefix delegation hop limit %d out of range", pd.HopLimit)
+			}
+
+			for routeIndex, route := range pd.AdvertisedRoutes {
+				_, subnet, err := net.ParseCIDR(route.Route)
+				if err != nil || subnet.IP.To4() != nil {
+					return fmt.Errorf("prefix delegation advertised route %d has invalid IPv6 prefix '%s'", routeIndex, route.Route)
+				}
+
+				if subnet.String() != route.Route {
+					return fmt.Errorf("prefix delegation advertised route %d prefix '%s' has host bits set", routeIndex, route.Route)
+				}
+
+				if route.Lifetime == "" {
+					continue
+				}
+
+				// The RA route information option carries the lifetime as a 32-bit number of seconds.
+				lifetime, err := time.ParseDuration(route.Lifetime)
+				if err != nil || lifetime < time.Second || lifetime.Seconds() > math.MaxUint32 {
+					return fmt.Errorf("prefix delegation advertised route %d has invalid lifetime '%s'", routeIndex, route.Lifetime)
+				}
+			}
+		default:
+			return fmt.Errorf("invalid prefix delegation mode '%s'", pd.Mode)
+		}
+
+		return nil
+	}
+
+	for index, iface := range cfg.Interfaces {
+		err := checkPrefixDelegation(iface.PrefixDelegation, iface.Addresses)
+		if err != nil {
+			return newValidationError("interface", index, iface.Name, "prefix_delegation", ValidationCodeInvalid, err)
+		}
+	}
+
+	for index, bond := range cfg.Bonds {
+		err := checkPrefixDelegation(bond.PrefixDelegation, bond.Addresses)
+		if err != nil {
+			return newValidationError("bond", index, bond.Name, "prefix_delegation", ValidationCodeInvalid, err)
+		}
+	}
+
+	for index, vlan := range cfg.VLANs {
+		err := checkPrefixDelegation(vlan.PrefixDelegation, vlan.Addresses)
+		if err != nil {
+			return newValidationError("vlan", index, vlan.Name, "prefix_delegation", ValidationCodeInvalid, err)
+		}
+	}
+
+	if numDownstreams > 0 && numUplinks == 0 {
+		return errors.New("prefix delegation requires an uplink device")
+	}
+
+	if numUplinks > 1 {
+		return errors.New("only one device can be the prefix delegation uplink")
+	}
+
+	return nil
+}
+
+// validateIgnore checks that each ignored pattern is usable and doesn't match a configured device.
+func validateIgnore(cfg *api.SystemNetworkConfig) error {
+	for index, pattern := range cfg.Ignore {
+		if pattern == "" {
+			return fmt.Errorf("ignore %d pattern is empty", index)
+		}
+
+		if strings.ContainsAny(pattern, " \t\n") {
+			return fmt.Errorf("ignore %d pattern '%s' contains whitespace", index, pattern)
+		}
+
+		// Don't allow ignoring a device that is part of the configuration.
+		used := slices.ContainsFunc(cfg.Interfaces, func(iface api.SystemNetworkInterface) bool { return strings.EqualFold(iface.Hwaddr, pattern) })
+		used = used || slices.ContainsFunc(cfg.Bonds, func(bond api.SystemNetworkBond) bool {
+			return slices.ContainsFunc(bond.Members, func(m string) bool { return strings.EqualFold(m, pattern) })
+		})
+		used = used || slices.ContainsFunc(cfg.Teams, func(team api.SystemNetworkTeam) bool {
+			return slices.ContainsFunc(team.Members, func(m string) bool { return strings.EqualFold(m, pattern) })
+		})
+
+		if used {
+			return fmt.Errorf("ignore %d pattern '%s' matches a configured device", index, pattern)
+		}
+	}
+
+	return nil
+}
+
+// validateObserved checks that each observed device has a valid name and isn't managed by IncusOS.
+// The getHwaddr function returns the permanent MAC address of a device on the live system, or an
+// empty string if it doesn't exist. When nil, observed devices are only checked against names.
+func validateObserved(cfg *api.SystemNetworkConfig, names []string, getHwaddr func(name string) string) error {
+	observed := []string{}
+
+	for index, name := range cfg.Observed {
+		err := validateName(name)
+		if err != nil {
+			return fmt.Errorf("observed %d %s", index, err.Error())
+		}
+
+		if slices.Contains(observed, name) {
+			return fmt.Errorf("observed %d device '%s' is listed more than once", index, name)
+		}
+
+		observed = append(observed, name)
+
+		// Physical devices may be referenced by name rather than MAC address.
+		used := slices.Contains(names, name)
+		used = used || slices.ContainsFunc(cfg.Interfaces, func(iface api.SystemNetworkInterface) bool { return iface.Hwaddr == name })
+		used = used || slices.ContainsFunc(cfg.Bonds, func(bond api.SystemNetworkBond) bool { return slices.Contains(bond.Members, name) })
+		used = used || slices.ContainsFunc(cfg.Teams, func(team api.SystemNetworkTeam) bool { return slices.Contains(team.Members, name) })
+
+		// The observed device may also be a managed physical device under its MAC address.
+		hwaddr := ""
+		if getHwaddr != nil {
+			hwaddr = getHwaddr(name)
+		}
+
+		if hwaddr != "" {
+			matchesHwaddr := func(value string) bool { return strings.EqualFold(value, hwaddr) }
+
+			used = used || slices.ContainsFunc(cfg.Interfaces, func(iface api.SystemNetworkInterface) bool { return matchesHwaddr(iface.Hwaddr) })
+			used = used || slices.ContainsFunc(cfg.Bonds, func(bond api.SystemNetworkBond) bool { return slices.ContainsFunc(bond.Members, matchesHwaddr) })
+			used = used || slices.ContainsFunc(cfg.Teams, func(team api.SystemNetworkTeam) bool { return slices.ContainsFunc(team.Members, matchesHwaddr) })
+		}
+
+		if used {
+			return fmt.Errorf("observed %d device '%s' is managed by the configuration", index, name)
+		}
+	}
+
+	return nil
+}
+
+// getPhysicalNICHwaddr returns a lookup of the permanent MAC address of the provided physical NICs by name.
+func getPhysicalNICHwaddr(nics []physicalNIC) func(name string) string {
+	return func(name string) string {
+		for _, nic := range nics {
+			if nic.Name == name {
+				return nic.Hwaddr
+			}
+		}
+
+		return ""
+	}
+}
+
+// validateName checks a user provided device name. Any prefixes used to derive additional devices
+// from the name are taken into account, so that every device that will be created fits within
+// the kernel's name length limit.
+func validateName(name string, derivedPrefixes ...string) error {
+	if name == "" {
+		return errors.New("has no name")
+	}
+
+	if strings.HasPrefix(name, "_") {
+		return errors.New("name cannot begin with an underscore")
+	}
+
+	// Check the derived devices first, as they have the tightest limit.
+	for _, prefix := range derivedPrefixes {
+		if len(prefix+name) > maxDeviceNameLength {
+			return fmt.Errorf("name '%s' cannot be longer than %d characters (derived device '%s' would exceed %d characters)", name, maxDeviceNameLength-len(prefix), prefix+name, maxDeviceNameLength)
+		}
+	}
+
+	if len(name) > maxDeviceNameLength {
+		return fmt.Errorf("name '%s' cannot be longer than %d characters", name, maxDeviceNameLength)
+	}
+
+	return nil
+}
+
+func validateMode(mode string) error {
+	if mode != "balance-rr" && mode != "active-backup" && mode != "balance-xor" && mode != "broadcast" && mode != "802.3ad" && mode != "balance-tlb" && mode != "balance-alb" {
+		return fmt.Errorf("invalid Mode value '%s'", mode)
+	}
+
+	return nil
+}
+
+func validateTeamMode(mode string) error {
+	if mode != "roundrobin" && mode != "activebackup" && mode != "loadbalance" && mode != "broadcast" && mode != "lacp" {
+		return fmt.Errorf("invalid Mode value '%s'", mode)
+	}
+
+	return nil
+}
+
+func validateParent(parent string, interfaces []api.SystemNetworkInterface, bonds []api.SystemNetworkBond, teams []api.SystemNetworkTeam) error {
+	if parent == "" {
+		return errors.New("has no parent")
+	}
+
+	foundParent := false
+
+	for _, i := range interfaces {
+		if i.Name == parent {
+			foundParent = true
+
+			break
+		}
+	}
+
+	if !foundParent {
+		for _, b := range bonds {
+			if b.Name == parent {
+				foundParent = true
+
+				break
+			}
+		}
+	}
+
+	if !foundParent {
+		for _, t := range teams {
+			if t.Name == parent {
+				foundParent = true
+
+				break
+			}
+		}
+	}
+
+	if !foundParent {
+		return fmt.Errorf("unable to find parent '%s'", parent)
+	}
+
+	return nil
+}
+
+func validateRoles(roles []string) error {
+	existing := make([]string, 0, len(roles))
+
+	for _, role := range roles {
+		// Confirm role is valid.
+		if !slices.Contains([]string{api.SystemNetworkInterfaceRoleManagement, api.SystemNetworkInterfaceRoleCluster, api.SystemNetworkInterfaceRoleInstances, api.SystemNetworkInterfaceRoleStorage}, role) {
+			return fmt.Errorf("role %q is unsupported", role)
+		}
+
+		// Duplicate detection.
+		if slices.Contains(existing, role) {
+			return fmt.Errorf("role %q is listed multiple times", role)
+		}
+
+		existing = append(existing, role)
+	}
+
+	return nil
+}
+
+func validateFirewall(rules []api.SystemNetworkFirewallRule) error {
+	for _, rule := range rules {
+		// Check the action.
+		if !slices.Contains([]string{"accept", "drop", "reject"}, rule.Action) {
+			return fmt.Errorf("unsupported action %q", rule.Action)
+		}
+
+		// Check the protocol.
+		if !slices.Contains([]string{"", "tcp", "udp"}, rule.Protocol) {
+			return fmt.Errorf("unsupported protocol %q", rule.Protocol)
 		}
 
 		// Check the port.
@@ -388,90 +1857,1091 @@ func validateFirewall(rules []api.SystemNetworkFirewallRule) error {
 			return fmt.Errorf("invalid port %d", rule.Port)
 		}
 
-		// Check that a protocol is specified if a port is.
-		if rule.Port > 0 && rule.Protocol == "" {
-			return errors.New("port specified but no protocol provided")
+		// Check that a protocol is specified if a port is.
+		if rule.Port > 0 && rule.Protocol == "" {
+			return errors.New("port specified but no protocol provided")
+		}
+
+		// Check that a port is specified if a protocol is.
+		if rule.Protocol != "" && rule.Port == 0 {
+			return errors.New("protocol specified but no port provided")
+		}
+
+		// Check the address.
+		if rule.Source != "" {
+			var ip net.IP
+
+			if strings.Contains(rule.Source, "/") {
+				var err error
+
+				ip, _, err = net.ParseCIDR(rule.Source)
+				if err != nil {
+					return err
+				}
+			} else {
+				ip = net.ParseIP(rule.Source)
+			}
+
+			if ip == nil {
+				return fmt.Errorf("bad address %q", rule.Source)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateAddressOptions(options []api.SystemNetworkAddressOptions, addresses []string) error {
+	seen := []string{}
+
+	for index, o := range options {
+		if !slices.Contains(addresses, o.Address) || slices.Contains([]string{"dhcp4", "dhcp6", "slaac"}, o.Address) {
+			return fmt.Errorf("address option %d address '%s' isn't one of the static addresses", index, o.Address)
+		}
+
+		if slices.Contains(seen, o.Address) {
+			return fmt.Errorf("address option %d duplicate address '%s'", index, o.Address)
+		}
+
+		seen = append(seen, o.Address)
+
+		if len(o.Label) > 15 {
+			return fmt.Errorf("address option %d label '%s' is longer than 15 characters", index, o.Label)
+		}
+
+		if o.Scope != "" && !slices.Contains([]string{"global", "link", "host"}, o.Scope) {
+			scope, err := strconv.Atoi(o.Scope)
+			if err != nil || scope < 0 || scope > 255 {
+				return fmt.Errorf("address option %d invalid scope '%s'", index, o.Scope)
+			}
+		}
+
+		if !slices.Contains([]string{"", "forever", "infinity", "0"}, o.PreferredLifetime) {
+			return fmt.Errorf("address option %d invalid preferred lifetime '%s'", index, o.PreferredLifetime)
+		}
+
+		if o.HomeAddress && !strings.Contains(o.Address, ":") {
+			return fmt.Errorf("address option %d home address requires an IPv6 address", index)
+		}
+	}
+
+	return nil
+}
+
+func validateDHCPClass(vendorClass string, userClass []string, addresses []string) error {
+	if vendorClass == "" && len(userClass) == 0 {
+		return nil
+	}
+
+	if !slices.Contains(addresses, "dhcp4") {
+		return errors.New("DHCP vendor and user classes require a 'dhcp4' address")
+	}
+
+	// Both are sent as DHCP options, limited to 255 bytes.
+	if len(vendorClass) > 255 || strings.ContainsAny(vendorClass, "\n\r") {
+		return errors.New("invalid DHCP vendor class")
+	}
+
+	total := 0
+
+	for _, class := range userClass {
+		if class == "" || len(class) > 255 || strings.ContainsAny(class, " \t\n\r") {
+			return fmt.Errorf("invalid DHCP user class '%s'", class)
+		}
+
+		total += len(class) + 1
+	}
+
+	if total > 255 {
+		return errors.New("DHCP user classes can't exceed 255 bytes in total")
+	}
+
+	return nil
+}
+
+func validateRouteMetric(linkType string, routeMetric int) error {
+	if !slices.Contains([]string{"", "wired", "wireless", "lte"}, linkType) {
+		return fmt.Errorf("invalid link type '%s'", linkType)
+	}
+
+	if routeMetric < 0 {
+		return errors.New("route metric can't be negative")
+	}
+
+	return nil
+}
+
+func validateDisableIPv6(disableIPv6 bool, addresses []string) error {
+	if !disableIPv6 {
+		return nil
+	}
+
+	for _, address := range addresses {
+		if address == "dhcp6" || address == "slaac" || address == "link-local" {
+			return fmt.Errorf("address '%s' requires IPv6", address)
+		}
+
+		ip, _, err := net.ParseCIDR(address)
+		if err == nil && ip.To4() == nil {
+			return fmt.Errorf("IPv6 address '%s' can't be used with IPv6 disabled", address)
+		}
+	}
+
+	return nil
+}
+
+func validateIPMasquerade(ipMasquerade string, addresses []string) error {
+	if !slices.Contains([]string{"", "no", "ipv4", "ipv6", "both"}, ipMasquerade) {
+		return fmt.Errorf("invalid IP masquerade value '%s'", ipMasquerade)
+	}
+
+	if ipMasquerade != "" && ipMasquerade != "no" && len(addresses) == 0 {
+		return errors.New("IP masquerading requires at least one address")
+	}
+
+	return nil
+}
+
+func validateKeepConfiguration(keepConfiguration string) error {
+	if !slices.Contains([]string{"", "no", "yes", "static", "dhcp"}, keepConfiguration) {
+		return fmt.Errorf("invalid keep configuration value '%s'", keepConfiguration)
+	}
+
+	return nil
+}
+
+func validateIPForwarding(ipForwarding string, addresses []string) error {
+	if !slices.Contains([]string{"", "no", "ipv4", "ipv6", "both"}, ipForwarding) {
+		return fmt.Errorf("invalid IP forwarding value '%s'", ipForwarding)
+	}
+
+	if ipForwarding != "" && ipForwarding != "no" && len(addresses) == 0 {
+		return errors.New("IP forwarding requires at least one address")
+	}
+
+	return nil
+}
+
+func validateProxyNDPAddresses(addresses []string) error {
+	for _, address := range addresses {
+		ip := net.ParseIP(address)
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid IPv6 proxy NDP address '%s'", address)
+		}
+	}
+
+	return nil
+}
+
+// validateFilePriority checks that a shifted filename prefix stays within the .netdev and .network ranges.
+func validateFilePriority(offset int, priority int) error {
+	if offset+priority < 0 || offset+priority > 9 {
+		return fmt.Errorf("priority %d out of range (%d to %d)", priority, -offset, 9-offset)
+	}
+
+	return nil
+}
+
+func validateMTU(mtu int) error {
+	if mtu < 0 || mtu > 9000 {
+		return errors.New("MTU out of range")
+	}
+
+	return nil
+}
+
+func validateAddress(address string) error {
+	if address == "" {
+		return errors.New("has empty address")
+	}
+
+	if address == "dhcp4" || address == "dhcp6" || address == "slaac" {
+		return nil
+	}
+
+	addressishRegex := regexp.MustCompile(`^[.:[:xdigit:]]+$`)
+	if !addressishRegex.MatchString(address) {
+		return fmt.Errorf("invalid IP address '%s'", address)
+	}
+
+	return nil
+}
+
+// validateRouteVia validates the gateway(s) of a route. Multipath gateways must be reachable through one
+// of the device's configured subnets.
+func validateRouteVia(route api.SystemNetworkRoute, addresses []string) error {
+	if len(route.Gateways) == 0 {
+		err := validateAddress(route.Via)
+		if err != nil {
+			return fmt.Errorf("'Via' %s", err.Error())
+		}
+
+		return nil
+	}
+
+	if route.Via != "" {
+		return errors.New("cannot specify both 'Via' and gateways")
+	}
+
+	for gatewayIndex, gateway := range route.Gateways {
+		ip := net.ParseIP(gateway.Address)
+		if ip == nil {
+			return fmt.Errorf("gateway %d invalid IP address '%s'", gatewayIndex, gateway.Address)
+		}
+
+		if gateway.Weight < 0 || gateway.Weight > 256 {
+			return fmt.Errorf("gateway %d weight %d out of range", gatewayIndex, gateway.Weight)
+		}
+
+		if !isGatewayReachable(ip, addresses) {
+			return fmt.Errorf("gateway %d '%s' isn't reachable on any configured subnet", gatewayIndex, gateway.Address)
+		}
+	}
+
+	return nil
+}
+
+func validateRouteSource(route api.SystemNetworkRoute, addresses []string) error {
+	if route.Source == "" {
+		return nil
+	}
+
+	source := net.ParseIP(route.Source)
+	if source == nil {
+		return fmt.Errorf("invalid source address '%s'", route.Source)
+	}
+
+	for _, address := range addresses {
+		ip, _, err := net.ParseCIDR(address)
+		if err == nil && ip.Equal(source) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("source address '%s' isn't one of the configured addresses", route.Source)
+}
+
+func validateRouteBFD(route api.SystemNetworkRoute) error {
+	if route.BFD == nil {
+		return nil
+	}
+
+	_, _, err := net.ParseCIDR(route.To)
+	if err != nil {
+		return errors.New("BFD requires 'To' to be a subnet")
+	}
+
+	if net.ParseIP(route.Via) == nil {
+		return errors.New("BFD requires 'Via' to be an IP address")
+	}
+
+	if route.Table != 0 || route.Source != "" || route.MTU != 0 {
+		return errors.New("BFD cannot be combined with a table, source address or MTU")
+	}
+
+	if route.BFD.DetectMultiplier != 0 && (route.BFD.DetectMultiplier < 2 || route.BFD.DetectMultiplier > 255) {
+		return fmt.Errorf("BFD detect multiplier %d out of range", route.BFD.DetectMultiplier)
+	}
+
+	if route.BFD.ReceiveInterval != 0 && (route.BFD.ReceiveInterval < 10 || route.BFD.ReceiveInterval > 60000) {
+		return fmt.Errorf("BFD receive interval %d out of range", route.BFD.ReceiveInterval)
+	}
+
+	if route.BFD.TransmitInterval != 0 && (route.BFD.TransmitInterval < 10 || route.BFD.TransmitInterval > 60000) {
+		return fmt.Errorf("BFD transmit interval %d out of range", route.BFD.TransmitInterval)
+	}
+
+	return nil
+}
+
+func validateRouteMTU(route api.SystemNetworkRoute, deviceMTU int) error {
+	if route.MTU == 0 {
+		return nil
+	}
+
+	err := validateMTU(route.MTU)
+	if err != nil {
+		return err
+	}
+
+	if deviceMTU != 0 && route.MTU > deviceMTU {
+		return fmt.Errorf("MTU %d is larger than the device MTU %d", route.MTU, deviceMTU)
+	}
+
+	return nil
+}
+
+// isGatewayReachable checks if a gateway is link-local, within one of the static subnets, or of an
+// address family that is dynamically configured.
+func isGatewayReachable(ip net.IP, addresses []string) bool {
+	if ip.IsLinkLocalUnicast() {
+		return true
+	}
+
+	isIPv4 := ip.To4() != nil
+
+	for _, address := range addresses {
+		switch address {
+		case "dhcp4":
+			if isIPv4 {
+				return true
+			}
+
+		case "dhcp6", "slaac":
+			if !isIPv4 {
+				return true
+			}
+
+		default:
+			_, subnet, err := net.ParseCIDR(address)
+			if err == nil && subnet.Contains(ip) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// validateDeviceAddress checks an address of an interface, bond or VLAN, which can also be "link-local".
+func validateDeviceAddress(address string) error {
+	if address == "link-local" {
+		return nil
+	}
+
+	return validateAddressWithCIDR(address)
+}
+
+// validateLinkLocalOnly ensures a "link-local" address isn't combined with any other address.
+func validateLinkLocalOnly(addresses []string) error {
+	if slices.Contains(addresses, "link-local") && len(addresses) > 1 {
+		return errors.New("address 'link-local' can't be combined with other addresses")
+	}
+
+	return nil
+}
+
+func validateAddressWithCIDR(address string) error {
+	if address == "" {
+		return errors.New("has empty address")
+	}
+
+	if address == "dhcp4" || address == "dhcp6" || address == "slaac" {
+		return nil
+	}
+
+	addressishRegex := regexp.MustCompile(`^[.:[:xdigit:]]+/\d+$`)
+	if !addressishRegex.MatchString(address) {
+		return fmt.Errorf("invalid IP address '%s', must provide a CIDR mask", address)
+	}
+
+	return nil
+}
+
+func validateRequiredForOnline(val string) error {
+	if val != "" && val != "ipv6" && val != "ipv4" && val != "both" && val != "ipv4+ipv6" && val != "any" && val != "no" {
+		return fmt.Errorf("invalid RequiredForOnline value '%s'", val)
+	}
+
+	return nil
+}
+
+func validateMACAddressPolicy(policy string, macAddress string) error {
+	if policy != "" && policy != "persistent" && policy != "random" && policy != "none" {
+		return fmt.Errorf("invalid MACAddressPolicy value '%s'", policy)
+	}
+
+	if macAddress != "" {
+		if policy != "" {
+			return errors.New("cannot specify both a MAC address and a MAC address policy")
+		}
+
+		err := validateHwaddr(macAddress, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDot1X(dot1x *api.SystemNetworkDot1X) error {
+	if dot1x == nil {
+		return nil
+	}
+
+	// Values are written as quoted strings in the wpa_supplicant config.
+	for _, val := range []string{dot1x.Identity, dot1x.AnonymousIdentity, dot1x.Password, dot1x.ClientKeyPassword} {
+		if strings.ContainsAny(val, "\"\n") {
+			return errors.New("802.1X credentials cannot contain quotes or newlines")
+		}
+	}
+
+	if dot1x.Identity == "" {
+		return errors.New("802.1X requires an identity")
+	}
+
+	switch dot1x.EAPMethod {
+	case "tls":
+		if dot1x.ClientCertificate == "" || dot1x.ClientKey == "" {
+			return errors.New("802.1X EAP-TLS requires a client certificate and key")
+		}
+
+	case "peap", "ttls":
+		if dot1x.Password == "" {
+			return fmt.Errorf("802.1X EAP-%s requires a password", strings.ToUpper(dot1x.EAPMethod))
+		}
+
+		if dot1x.Phase2Method != "" && !slices.Contains([]string{"chap", "gtc", "mschap", "mschapv2", "pap"}, dot1x.Phase2Method) {
+			return fmt.Errorf("invalid 802.1X phase2 method '%s'", dot1x.Phase2Method)
+		}
+
+	default:
+		return fmt.Errorf("invalid 802.1X EAP method '%s'", dot1x.EAPMethod)
+	}
+
+	return nil
+}
+
+func validateQoS(qos *api.SystemNetworkQoS) error {
+	if qos == nil {
+		return nil
+	}
+
+	if qos.Bandwidth != "" && len(qos.Classes) > 0 {
+		return errors.New("QoS cannot specify both a CAKE bandwidth and HTB classes")
+	}
+
+	if qos.Bandwidth != "" && !isValidBandwidth(qos.Bandwidth) {
+		return fmt.Errorf("invalid QoS bandwidth '%s'", qos.Bandwidth)
+	}
+
+	classIDs := []int{}
+
+	for classIndex, class := range qos.Classes {
+		if class.ID < 1 || class.ID > 0xffff {
+			return fmt.Errorf("QoS class %d ID %d out of range", classIndex, class.ID)
+		}
+
+		if slices.Contains(classIDs, class.ID) {
+			return fmt.Errorf("QoS class %d duplicate ID %d", classIndex, class.ID)
+		}
+
+		classIDs = append(classIDs, class.ID)
+
+		if !isValidBandwidth(class.Rate) {
+			return fmt.Errorf("QoS class %d invalid rate '%s'", classIndex, class.Rate)
+		}
+
+		if class.CeilRate != "" && !isValidBandwidth(class.CeilRate) {
+			return fmt.Errorf("QoS class %d invalid ceil rate '%s'", classIndex, class.CeilRate)
+		}
+
+		if class.Priority < 0 || class.Priority > 7 {
+			return fmt.Errorf("QoS class %d priority %d out of range", classIndex, class.Priority)
+		}
+	}
+
+	if qos.DefaultClass != 0 && !slices.Contains(classIDs, qos.DefaultClass) {
+		return fmt.Errorf("QoS default class %d doesn't exist", qos.DefaultClass)
+	}
+
+	return nil
+}
+
+// validateBridgeVLAN checks the bridge's default PVID and VLAN protocol against the device's port VLAN settings.
+func validateBridgeVLAN(defaultPVID *int, vlanProtocol string, vlanTags []int, addresses []string) error {
+	if vlanProtocol != "" && vlanProtocol != "802.1q" && vlanProtocol != "802.1ad" {
+		return fmt.Errorf("invalid VLAN protocol '%s'", vlanProtocol)
+	}
+
+	if defaultPVID == nil {
+		return nil
+	}
+
+	if *defaultPVID < 0 || *defaultPVID > 4094 {
+		return fmt.Errorf("default PVID %d out of range", *defaultPVID)
+	}
+
+	// Without a default PVID, untagged host traffic would be dropped by the bridge.
+	if *defaultPVID == 0 && len(addresses) > 0 {
+		return errors.New("default PVID 0 drops untagged traffic, but addresses are configured")
+	}
+
+	if *defaultPVID != 0 && slices.Contains(vlanTags, *defaultPVID) {
+		return fmt.Errorf("default PVID %d is also listed as a tagged VLAN", *defaultPVID)
+	}
+
+	return nil
+}
+
+func validateBridgePort(cost int, priority *int) error {
+	if cost < 0 || cost > 65535 {
+		return fmt.Errorf("bridge port cost %d out of range", cost)
+	}
+
+	if priority != nil && (*priority < 0 || *priority > 63) {
+		return fmt.Errorf("bridge port priority %d out of range", *priority)
+	}
+
+	return nil
+}
+
+// validateDefaultPVIDOverlap checks that the default PVID of an interface or bond isn't also the ID of a VLAN on top of it,
+// which would otherwise be silently merged into the bridge's tagged VLANs.
+func validateDefaultPVIDOverlap(cfg *api.SystemNetworkConfig) error {
+	checkOverlap := func(name string, defaultPVID *int) error {
+		if defaultPVID == nil || *defaultPVID == 0 {
+			return nil
+		}
+
+		for _, vlan := range cfg.VLANs {
+			if vlan.Parent == name && vlan.ID == *defaultPVID {
+				return fmt.Errorf("default PVID %d is also used by VLAN '%s'", *defaultPVID, vlan.Name)
+			}
+		}
+
+		return nil
+	}
+
+	for index, iface := range cfg.Interfaces {
+		err := checkOverlap(iface.Name, iface.DefaultPVID)
+		if err != nil {
+			return newValidationError("interface", index, iface.Name, "default_pvid", ValidationCodeInvalid, err)
+		}
+	}
+
+	for index, bond := range cfg.Bonds {
+		err := checkOverlap(bond.Name, bond.DefaultPVID)
+		if err != nil {
+			return newValidationError("bond", index, bond.Name, "default_pvid", ValidationCodeInvalid, err)
+		}
+	}
+
+	return nil
+}
+
+// isValidNameserver checks if a name server is an IP address, optionally followed by an interface,
+// a port and a server name as accepted by systemd-networkd (e.g. "[2001:db8::53]:853#dns.example.org").
+func isValidNameserver(ns string) bool {
+	address, _, _ := strings.Cut(ns, "#")
+
+	if net.ParseIP(address) != nil {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err == nil {
+		address = host
+	}
+
+	address, _, _ = strings.Cut(address, "%")
+
+	return net.ParseIP(address) != nil
+}
+
+// validateExtraOptions checks that extra options only target known systemd.network sections.
+func validateExtraOptions(extraOptions map[string][]string) error {
+	knownSections := []string{
+		"Address", "BFIFO", "Bridge", "BridgeFDB", "BridgeMDB", "BridgeVLAN", "CAKE", "CAN", "ControlledDelay",
+		"DeficitRoundRobinScheduler", "DeficitRoundRobinSchedulerClass", "DHCP", "DHCPPrefixDelegation", "DHCPServer",
+		"DHCPServerStaticLease", "DHCPv4", "DHCPv6", "EnhancedTransmissionSelection", "FairQueueing",
+		"FairQueueingControlledDelay", "FlowQueuePIE", "GenericRandomEarlyDetection", "HeavyHitterFilter",
+		"HierarchyTokenBucket", "HierarchyTokenBucketClass", "IPoIB", "IPv6AcceptRA", "IPv6AddressLabel", "IPv6Prefix",
+		"IPv6PREF64Prefix", "IPv6RoutePrefix", "IPv6SendRA", "Link", "LLDP", "Neighbor", "Network", "NetworkEmulator",
+		"NextHop", "PFIFO", "PFIFOFast", "PFIFOHeadDrop", "PIE", "QDisc", "QuickFairQueueing", "QuickFairQueueingClass",
+		"Route", "RoutingPolicyRule", "SR-IOV", "StochasticFairBlue", "StochasticFairnessQueueing", "TokenBucketFilter",
+		"TrivialLinkEqualizer",
+	}
+
+	for section, options := range extraOptions {
+		if !slices.Contains(knownSections, section) {
+			return fmt.Errorf("extra options unknown section '%s'", section)
+		}
+
+		for optionIndex, option := range options {
+			key, _, found := strings.Cut(option, "=")
+			if !found || strings.TrimSpace(key) == "" || strings.ContainsAny(option, "\r\n") {
+				return fmt.Errorf("extra options section '%s' option %d invalid '%s'", section, optionIndex, option)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateNTPServers(ntpServers []string) error {
+	for ntpIndex, ntp := range ntpServers {
+		if ntp == "" {
+			return fmt.Errorf("NTP server %d is empty", ntpIndex)
+		}
+	}
+
+	return nil
+}
+
+func validateCarrierDelay(carrierDelay string) error {
+	if carrierDelay == "" {
+		return nil
+	}
+
+	delay, err := time.ParseDuration(carrierDelay)
+	if err != nil {
+		return fmt.Errorf("invalid carrier delay '%s'", carrierDelay)
+	}
+
+	if delay <= 0 || delay > 5*time.Minute {
+		return fmt.Errorf("carrier delay '%s' out of range", carrierDelay)
+	}
+
+	return nil
+}
+
+func validateLinkDNS(dns *api.SystemNetworkLinkDNS) error {
+	if dns == nil {
+		return nil
+	}
+
+	for nsIndex, ns := range dns.Nameservers {
+		if !isValidNameserver(ns) {
+			return fmt.Errorf("DNS nameserver %d invalid IP address '%s'", nsIndex, ns)
+		}
+	}
+
+	return validateDNSDomains(dns.SearchDomains, dns.RoutingDomains)
+}
+
+// validateDNSDomains checks the syntax of the search and routing-only domains. A routing domain of "."
+// routes all queries not matching a more specific domain.
+func validateDNSDomains(searchDomains []string, routingDomains []string) error {
+	domainRegex := regexp.MustCompile(`^[[:alnum:]_]([[:alnum:]_-]{0,61}[[:alnum:]])?(\.[[:alnum:]_]([[:alnum:]_-]{0,61}[[:alnum:]])?)*\.?$`)
+
+	for domainIndex, domain := range searchDomains {
+		if domain == "" {
+			return fmt.Errorf("DNS search domain %d is empty", domainIndex)
 		}
 
-		// Check that a port is specified if a protocol is.
-		if rule.Protocol != "" && rule.Port == 0 {
-			return errors.New("protocol specified but no port provided")
+		// A leading "~" makes the domain routing-only, as in routing_domains.
+		name := strings.TrimPrefix(domain, "~")
+		if domain == "~." {
+			continue
 		}
 
-		// Check the address.
-		if rule.Source != "" {
-			var ip net.IP
+		if len(name) > 253 || !domainRegex.MatchString(name) {
+			return fmt.Errorf("DNS search domain %d is an invalid domain '%s'", domainIndex, domain)
+		}
+	}
 
-			if strings.Contains(rule.Source, "/") {
-				var err error
+	for domainIndex, domain := range routingDomains {
+		if domain == "." {
+			continue
+		}
 
-				ip, _, err = net.ParseCIDR(rule.Source)
-				if err != nil {
-					return err
-				}
-			} else {
-				ip = net.ParseIP(rule.Source)
-			}
+		if len(domain) > 253 || !domainRegex.MatchString(domain) {
+			return fmt.Errorf("DNS routing domain %d is an invalid domain '%s'", domainIndex, domain)
+		}
+	}
 
-			if ip == nil {
-				return fmt.Errorf("bad address %q", rule.Source)
-			}
+	return nil
+}
+
+func validateStaticHosts(dns *api.SystemNetworkDNS) error {
+	if dns == nil {
+		return nil
+	}
+
+	hostnameRegex := regexp.MustCompile(`^[[:alnum:]]([[:alnum:]-]{0,61}[[:alnum:]])?(\.[[:alnum:]]([[:alnum:]-]{0,61}[[:alnum:]])?)*$`)
+
+	for host, address := range dns.StaticHosts {
+		if len(host) > 253 || !hostnameRegex.MatchString(host) {
+			return fmt.Errorf("DNS static host '%s' is an invalid hostname", host)
+		}
+
+		if net.ParseIP(address) == nil {
+			return fmt.Errorf("DNS static host '%s' invalid IP address '%s'", host, address)
 		}
 	}
 
 	return nil
 }
 
-func validateMTU(mtu int) error {
-	if mtu < 0 || mtu > 9000 {
-		return errors.New("MTU out of range")
+// validateNeighbors checks that each static neighbor has a valid IP address and MAC address.
+func validateNeighbors(neighbors []api.SystemNetworkNeighbor) error {
+	for index, n := range neighbors {
+		if net.ParseIP(n.Address) == nil {
+			return fmt.Errorf("neighbor %d invalid IP address '%s'", index, n.Address)
+		}
+
+		// Both permanent and reachable entries need a MAC, as the kernel won't resolve a seeded entry.
+		err := validateHwaddr(n.Hwaddr, true)
+		if err != nil {
+			return fmt.Errorf("neighbor %d %w", index, err)
+		}
 	}
 
 	return nil
 }
 
-func validateAddress(address string) error {
-	if address == "" {
-		return errors.New("has empty address")
+// getBridgeVLANs returns the VLANs carried by a bridged device, from its own VLAN tags and any VLAN using it as parent.
+func getBridgeVLANs(name string, vlanTags []int, vlans []api.SystemNetworkVLAN) []int {
+	ret := slices.Clone(vlanTags)
+
+	for _, vlan := range vlans {
+		if vlan.Parent == name {
+			ret = append(ret, vlan.ID)
+		}
 	}
 
-	if address == "dhcp4" || address == "dhcp6" || address == "slaac" {
+	return ret
+}
+
+// validateMACLockHwaddrs checks that the allowed MAC addresses of a locked bridge port are raw MAC addresses.
+func validateMACLockHwaddrs(macLock bool, hwaddrs []string) error {
+	if !macLock && len(hwaddrs) > 0 {
+		return errors.New("allowed MAC addresses can only be set with mac_lock")
+	}
+
+	for index, hwaddr := range hwaddrs {
+		err := validateHwaddr(hwaddr, true)
+		if err != nil {
+			return fmt.Errorf("allowed MAC address %d %w", index, err)
+		}
+	}
+
+	return nil
+}
+
+// validateBridgeFDB checks that each static bridge forwarding entry has a valid MAC address, destination
+// and, if set, a VLAN carried by the device.
+func validateBridgeFDB(entries []api.SystemNetworkBridgeFDB, vlans []int) error {
+	for index, entry := range entries {
+		err := validateHwaddr(entry.Hwaddr, true)
+		if err != nil {
+			return fmt.Errorf("bridge FDB %d %w", index, err)
+		}
+
+		if entry.VLAN != 0 && !slices.Contains(vlans, entry.VLAN) {
+			return fmt.Errorf("bridge FDB %d VLAN %d isn't carried by this device", index, entry.VLAN)
+		}
+
+		if !slices.Contains([]string{"", "uplink", "host"}, entry.Destination) {
+			return fmt.Errorf("bridge FDB %d invalid destination '%s'", index, entry.Destination)
+		}
+	}
+
+	return nil
+}
+
+// validateIPv6AddressGenerationMode checks that the IPv6 address generation mode is supported and that IPv6 is enabled.
+func validateIPv6AddressGenerationMode(mode string, disableIPv6 bool) error {
+	if mode == "" {
 		return nil
 	}
 
-	addressishRegex := regexp.MustCompile(`^[.:[:xdigit:]]+$`)
-	if !addressishRegex.MatchString(address) {
-		return fmt.Errorf("invalid IP address '%s'", address)
+	if !slices.Contains([]string{"eui64", "none", "stable-privacy", "random"}, mode) {
+		return fmt.Errorf("invalid IPv6 address generation mode '%s'", mode)
+	}
+
+	if disableIPv6 {
+		return errors.New("IPv6 address generation mode can't be set when IPv6 is disabled")
 	}
 
 	return nil
 }
 
-func validateAddressWithCIDR(address string) error {
+// validateDHCPFallbackAddress checks that the fallback address is a valid CIDR address of a family
+// configured through DHCP, which isn't already used as a static address.
+func validateDHCPFallbackAddress(address string, addresses []string) error {
 	if address == "" {
-		return errors.New("has empty address")
+		return nil
 	}
 
-	if address == "dhcp4" || address == "dhcp6" || address == "slaac" {
+	ip, _, err := net.ParseCIDR(address)
+	if err != nil {
+		return fmt.Errorf("invalid DHCP fallback address '%s', must be in CIDR notation", address)
+	}
+
+	if ip.To4() != nil && !slices.Contains(addresses, "dhcp4") {
+		return fmt.Errorf("DHCP fallback address '%s' requires dhcp4", address)
+	}
+
+	if ip.To4() == nil && !slices.Contains(addresses, "dhcp6") && !slices.Contains(addresses, "slaac") {
+		return fmt.Errorf("DHCP fallback address '%s' requires dhcp6 or slaac", address)
+	}
+
+	if slices.Contains(addresses, address) {
+		return fmt.Errorf("DHCP fallback address '%s' is already a static address", address)
+	}
+
+	return nil
+}
+
+// validateVRRP checks that each VRRP virtual router has a unique VRID and that its virtual addresses
+// are all of the same family and within the subnet of one of the device's static addresses.
+func validateVRRP(vrrps []api.SystemNetworkVRRP, addresses []string) error {
+	subnets := []*net.IPNet{}
+
+	for _, address := range addresses {
+		_, subnet, err := net.ParseCIDR(address)
+		if err == nil {
+			subnets = append(subnets, subnet)
+		}
+	}
+
+	vrids := map[int]bool{}
+
+	for index, vrrp := range vrrps {
+		if vrrp.VRID < 1 || vrrp.VRID > 255 {
+			return fmt.Errorf("VRRP %d VRID must be between 1 and 255", index)
+		}
+
+		if vrids[vrrp.VRID] {
+			return fmt.Errorf("VRRP %d VRID %d is already in use on this device", index, vrrp.VRID)
+		}
+
+		vrids[vrrp.VRID] = true
+
+		// A priority of 255 is reserved for the owner of the virtual addresses.
+		if vrrp.Priority < 0 || vrrp.Priority > 254 {
+			return fmt.Errorf("VRRP %d priority must be between 1 and 254", index)
+		}
+
+		if vrrp.AdvertInterval < 0 || vrrp.AdvertInterval > 255 {
+			return fmt.Errorf("VRRP %d advertisement interval must be between 1 and 255 seconds", index)
+		}
+
+		if len(vrrp.VirtualAddresses) == 0 {
+			return fmt.Errorf("VRRP %d has no virtual addresses", index)
+		}
+
+		ipv6 := false
+
+		for addrIndex, addr := range vrrp.VirtualAddresses {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return fmt.Errorf("VRRP %d invalid virtual address '%s'", index, addr)
+			}
+
+			if addrIndex == 0 {
+				ipv6 = ip.To4() == nil
+			} else if ipv6 != (ip.To4() == nil) {
+				return fmt.Errorf("VRRP %d can't mix IPv4 and IPv6 virtual addresses", index)
+			}
+
+			if !slices.ContainsFunc(subnets, func(subnet *net.IPNet) bool { return subnet.Contains(ip) }) {
+				return fmt.Errorf("VRRP %d virtual address '%s' isn't within any of the device's static subnets", index, addr)
+			}
+		}
+
+		if vrrp.AuthPassword != "" {
+			if ipv6 {
+				return fmt.Errorf("VRRP %d authentication isn't supported with IPv6 virtual addresses", index)
+			}
+
+			if len(vrrp.AuthPassword) > 8 {
+				return fmt.Errorf("VRRP %d authentication password can't be longer than 8 characters", index)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateBondActor checks the 802.3ad actor system, actor system priority and user port key of a bond.
+func validateBondActor(bond api.SystemNetworkBond) error {
+	if bond.AdActorSystem == "" && bond.AdActorSystemPriority == 0 && bond.AdUserPortKey == 0 {
 		return nil
 	}
 
-	addressishRegex := regexp.MustCompile(`^[.:[:xdigit:]]+/\d+$`)
-	if !addressishRegex.MatchString(address) {
-		return fmt.Errorf("invalid IP address '%s', must provide a CIDR mask", address)
+	if bond.Mode != "802.3ad" {
+		return errors.New("actor system, actor system priority and user port key can only be used with 802.3ad mode")
+	}
+
+	if bond.AdActorSystemPriority < 0 || bond.AdActorSystemPriority > 65535 {
+		return fmt.Errorf("actor system priority %d out of range (1 to 65535)", bond.AdActorSystemPriority)
+	}
+
+	if bond.AdUserPortKey < 0 || bond.AdUserPortKey > 1023 {
+		return fmt.Errorf("user port key %d out of range (0 to 1023)", bond.AdUserPortKey)
+	}
+
+	if bond.AdActorSystem != "" {
+		err := validateHwaddr(bond.AdActorSystem, true)
+		if err != nil {
+			return fmt.Errorf("actor system %w", err)
+		}
+
+		// The kernel rejects multicast and all-zero actor systems.
+		hwaddr, _ := net.ParseMAC(bond.AdActorSystem)
+		if hwaddr[0]&0x01 != 0 || bytes.Equal(hwaddr, make(net.HardwareAddr, 6)) {
+			return fmt.Errorf("actor system '%s' must be a non-zero unicast MAC address", bond.AdActorSystem)
+		}
 	}
 
 	return nil
 }
 
-func validateRequiredForOnline(val string) error {
-	if val != "" && val != "ipv6" && val != "ipv4" && val != "both" && val != "any" && val != "no" {
-		return fmt.Errorf("invalid RequiredForOnline value '%s'", val)
+// validateUdevRules checks that each udev rule is a single line made only of ATTR{...}= and SYSCTL{...}= assignments.
+func validateUdevRules(rules []string) error {
+	tokenRegex := regexp.MustCompile(`^\s*([A-Z_]+)(\{[^{}"]*\})?\s*(==|!=|\+=|-=|:=|=)\s*"([^"]*)"\s*(,|$)`)
+
+	for index, rule := range rules {
+		if strings.TrimSpace(rule) == "" || strings.ContainsAny(rule, "\r\n") {
+			return fmt.Errorf("udev rule %d must be a single non-empty line", index)
+		}
+
+		remaining := rule
+		for remaining != "" {
+			match := tokenRegex.FindStringSubmatch(remaining)
+			if match == nil {
+				return fmt.Errorf("udev rule %d must be a comma-separated list of KEY{attribute}=\"value\" assignments", index)
+			}
+
+			if !slices.Contains([]string{"ATTR", "SYSCTL"}, match[1]) || match[2] == "" || match[3] != "=" {
+				return fmt.Errorf("udev rule %d can't use '%s%s%s', only ATTR{...}= and SYSCTL{...}= assignments are allowed", index, match[1], match[2], match[3])
+			}
+
+			remaining = remaining[len(match[0]):]
+			if match[5] == "," && strings.TrimSpace(remaining) == "" {
+				return fmt.Errorf("udev rule %d can't end with a comma", index)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateSysctls checks that each sysctl key is a per-device "ipv4" or "ipv6" setting with a plain value.
+func validateSysctls(sysctls map[string]string) error {
+	keyRegex := regexp.MustCompile(`^ipv[46]\.[a-z0-9_]+$`)
+	valueRegex := regexp.MustCompile(`^[[:alnum:]_.:-]+$`)
+
+	for _, key := range slices.Sorted(maps.Keys(sysctls)) {
+		if !keyRegex.MatchString(key) {
+			return fmt.Errorf("invalid sysctl '%s', must be of the form 'ipv4.<name>' or 'ipv6.<name>'", key)
+		}
+
+		if key == "ipv6.disable_ipv6" {
+			return errors.New("sysctl 'ipv6.disable_ipv6' can't be set directly, use disable_ipv6 instead")
+		}
+
+		if !valueRegex.MatchString(sysctls[key]) {
+			return fmt.Errorf("invalid value '%s' for sysctl '%s'", sysctls[key], key)
+		}
+	}
+
+	return nil
+}
+
+// validateAlternativeNames checks that each alternative name is a valid kernel alternative interface name.
+func validateAlternativeNames(names []string) error {
+	for _, name := range names {
+		// The kernel limits alternative names to 127 bytes, excluding the "/", ":" and whitespace characters.
+		if name == "" || name == "." || name == ".." || len(name) > 127 || strings.ContainsAny(name, "/: \t\n") {
+			return fmt.Errorf("invalid alternative name '%s'", name)
+		}
+
+		if strings.HasPrefix(name, "_") {
+			return fmt.Errorf("alternative name '%s' can't start with '_'", name)
+		}
+	}
+
+	return nil
+}
+
+// validateResolvedOptions checks the global systemd-resolved options.
+func validateResolvedOptions(dns *api.SystemNetworkDNS) error {
+	if dns == nil {
+		return nil
+	}
+
+	if dns.LLMNR != "" && !slices.Contains([]string{"yes", "no", "resolve"}, dns.LLMNR) {
+		return fmt.Errorf("invalid DNS LLMNR value '%s'", dns.LLMNR)
+	}
+
+	if dns.MulticastDNS != "" && !slices.Contains([]string{"yes", "no", "resolve"}, dns.MulticastDNS) {
+		return fmt.Errorf("invalid DNS multicast DNS value '%s'", dns.MulticastDNS)
+	}
+
+	if dns.Cache != "" && !slices.Contains([]string{"yes", "no", "no-negative"}, dns.Cache) {
+		return fmt.Errorf("invalid DNS cache value '%s'", dns.Cache)
+	}
+
+	if dns.CacheFromLocalhost && dns.Cache == "no" {
+		return errors.New("DNS cache from localhost requires the cache to be enabled")
+	}
+
+	return nil
+}
+
+// validateStaticTimeSources ensures that some NTP servers remain when those offered over DHCP are ignored.
+func validateStaticTimeSources(cfg *api.SystemNetworkConfig) error {
+	if cfg.Time == nil || !cfg.Time.IgnoreDHCP || len(cfg.Time.NTPServers) > 0 {
+		return nil
+	}
+
+	hasServers := slices.ContainsFunc(cfg.Interfaces, func(i api.SystemNetworkInterface) bool { return len(i.NTPServers) > 0 })
+	hasServers = hasServers || slices.ContainsFunc(cfg.Bonds, func(b api.SystemNetworkBond) bool { return len(b.NTPServers) > 0 })
+	hasServers = hasServers || slices.ContainsFunc(cfg.VLANs, func(v api.SystemNetworkVLAN) bool { return len(v.NTPServers) > 0 })
+
+	if !hasServers {
+		return errors.New("ignoring DHCP provided NTP servers requires at least one static NTP server")
+	}
+
+	return nil
+}
+
+// validateTime checks the NTP polling settings.
+func validateTime(timeCfg *api.SystemNetworkTime) error {
+	if timeCfg == nil {
+		return nil
+	}
+
+	parse := func(name string, value string, minimum time.Duration) (time.Duration, error) {
+		if value == "" {
+			return 0, nil
+		}
+
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s '%s'", name, value)
+		}
+
+		if d < minimum {
+			return 0, fmt.Errorf("%s '%s' must be at least %s", name, value, minimum)
+		}
+
+		return d, nil
+	}
+
+	// timesyncd doesn't poll more often than every 16 seconds.
+	pollIntervalMin, err := parse("poll interval min", timeCfg.PollIntervalMin, 16*time.Second)
+	if err != nil {
+		return err
+	}
+
+	pollIntervalMax, err := parse("poll interval max", timeCfg.PollIntervalMax, 16*time.Second)
+	if err != nil {
+		return err
+	}
+
+	if pollIntervalMax != 0 && pollIntervalMin > pollIntervalMax {
+		return errors.New("poll interval min can't be greater than poll interval max")
+	}
+
+	_, err = parse("root distance max", timeCfg.RootDistanceMax, time.Millisecond)
+	if err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// isValidBandwidth checks if a bandwidth is a number of bits per second with an optional K, M or G suffix.
+func isValidBandwidth(bandwidth string) bool {
+	return regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[KMG]?$`).MatchString(bandwidth)
+}
+
 func validateHwaddr(hwaddr string, requireValidMAC bool) error {
 	if hwaddr == "" {
 		return errors.New("has no MAC address")
@@ -506,5 +2976,46 @@ func validateEthernet(eth *api.SystemNetworkEthernet) error {
 		}
 	}
 
+	// Validate hardware queues and ring buffers.
+	if eth.CombinedChannels < 0 || eth.RxChannels < 0 || eth.TxChannels < 0 {
+		return errors.New("channel counts cannot be negative")
+	}
+
+	if eth.CombinedChannels > 0 && (eth.RxChannels > 0 || eth.TxChannels > 0) {
+		return errors.New("combined channels cannot be specified along with rx or tx channels")
+	}
+
+	if eth.RxBufferSize < 0 || eth.TxBufferSize < 0 {
+		return errors.New("buffer sizes cannot be negative")
+	}
+
+	// Validate interrupt coalescing delays.
+	validateCoalesce := func(name string, value string) error {
+		if value == "" {
+			return nil
+		}
+
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s coalesce value '%s'", name, value)
+		}
+
+		if d < 0 || d > time.Second {
+			return fmt.Errorf("%s coalesce value '%s' out of range (0s to 1s)", name, value)
+		}
+
+		return nil
+	}
+
+	err := validateCoalesce("rx", eth.RxCoalesce)
+	if err != nil {
+		return err
+	}
+
+	err = validateCoalesce("tx", eth.TxCoalesce)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
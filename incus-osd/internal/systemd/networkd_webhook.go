@@ -0,0 +1,163 @@
+package systemd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+var (
+	// muNetworkWebhook protects the cancellation of the running network webhook watcher.
+	muNetworkWebhook sync.Mutex
+
+	// cancelNetworkWebhook stops the running network webhook watcher, if any.
+	cancelNetworkWebhook context.CancelFunc
+)
+
+// restartNetworkWebhookWatcher stops any running webhook watcher and starts a new one if the configuration has a
+// webhook. The watcher works on its own copy of the network, so it doesn't race with later changes of the state.
+func restartNetworkWebhookWatcher(ctx context.Context, network api.SystemNetwork) {
+	muNetworkWebhook.Lock()
+	defer muNetworkWebhook.Unlock()
+
+	if cancelNetworkWebhook != nil {
+		cancelNetworkWebhook()
+		cancelNetworkWebhook = nil
+	}
+
+	if network.Config == nil || network.Config.Webhook == nil {
+		return
+	}
+
+	networkCfg := *network.Config
+	network.Config = &networkCfg
+
+	// The watcher outlives the request applying the configuration, until the next configuration replaces it.
+	watchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	cancelNetworkWebhook = cancel
+
+	go func() {
+		err := watchNetworkWebhook(watchCtx, network)
+		if err != nil {
+			slog.WarnContext(watchCtx, "Network webhook watcher stopped", "err", err)
+		}
+	}()
+}
+
+// watchNetworkWebhook notifies the network's webhook of every link state change, until the context is cancelled.
+func watchNetworkWebhook(ctx context.Context, network api.SystemNetwork) error {
+	webhook := *network.Config.Webhook
+
+	return WatchNetworkEvents(ctx, 5*time.Second, func(event api.SystemNetworkEvent) error {
+		if event.Type != NetworkEventLinkState {
+			return nil
+		}
+
+		// Refresh the state so the receiver gets the current status of every device.
+		err := UpdateNetworkState(ctx, &network)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to update network state", "err", err)
+		}
+
+		notifyNetworkWebhook(ctx, webhook, api.SystemNetworkWebhookEvent{
+			Event:     &event,
+			State:     network.State,
+			Timestamp: event.Timestamp,
+			Type:      event.Type,
+		})
+
+		return nil
+	})
+}
+
+// notifyNetworkWebhook sends the event to the webhook in the background, so the caller is never blocked.
+func notifyNetworkWebhook(ctx context.Context, webhook api.SystemNetworkWebhook, event api.SystemNetworkWebhookEvent) {
+	// Encode the body right away, as the state may change before it's sent.
+	body, err := json.Marshal(event)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to encode network webhook event", "err", err)
+
+		return
+	}
+
+	go func() {
+		err := sendNetworkWebhook(context.WithoutCancel(ctx), webhook, event.Type, body, 5)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to notify network webhook", "type", event.Type, "err", err)
+		}
+	}()
+}
+
+// sendNetworkWebhook POSTs the signed body to the webhook. Connection failures and server errors are
+// retried with an exponential backoff, up to the provided number of attempts.
+func sendNetworkWebhook(ctx context.Context, webhook api.SystemNetworkWebhook, eventType string, body []byte, attempts int) error {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}
+
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	_, _ = mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	delay := time.Second
+
+	for attempt := 1; ; attempt++ {
+		retryable, err := postNetworkWebhook(ctx, client, webhook.URL, eventType, signature, body)
+		if err == nil {
+			return nil
+		}
+
+		if !retryable || attempt >= attempts {
+			return fmt.Errorf("failed to notify '%s': %w", webhook.URL, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// postNetworkWebhook makes a single attempt at notifying the webhook, also reporting whether a failure may be transient.
+func postNetworkWebhook(ctx context.Context, client *http.Client, webhookURL string, eventType string, signature string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-IncusOS-Event", eventType)
+	req.Header.Set("X-IncusOS-Signature", signature)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+
+	_ = resp.Body.Close()
+
+	// Only server errors and rate limiting are considered transient.
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		retryable := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+
+		return retryable, fmt.Errorf("unexpected status '%s'", resp.Status)
+	}
+
+	return false, nil
+}
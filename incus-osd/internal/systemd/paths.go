@@ -15,4 +15,28 @@ var (
 
 	// SystemdTimesyncConfigFile is the configuration file for systemd-timesyncd.
 	SystemdTimesyncConfigFile = "/run/systemd/timesyncd.conf"
+
+	// SystemdResolvedConfigFile is the drop-in for global systemd-resolved options.
+	SystemdResolvedConfigFile = "/run/systemd/resolved.conf.d/50-incus-osd.conf"
+
+	// SysctlNetworkConfigFile is the sysctl drop-in for per-device network settings.
+	SysctlNetworkConfigFile = "/run/sysctl.d/50-incus-osd-network.conf"
+
+	// UdevNetworkRulesFile is the udev rules file for per-device network settings, processed before the .link files.
+	UdevNetworkRulesFile = "/run/udev/rules.d/70-incus-osd-network.rules"
+
+	// WpaSupplicantConfigPath is the location for wpa_supplicant config files.
+	WpaSupplicantConfigPath = "/etc/wpa_supplicant/"
+
+	// FRRConfigPath is the location for FRR config files.
+	FRRConfigPath = "/etc/frr/"
+
+	// TeamdConfigPath is the location for teamd config files.
+	TeamdConfigPath = "/run/teamd/"
+
+	// KeepalivedConfigPath is the location for keepalived config files.
+	KeepalivedConfigPath = "/run/keepalived/"
+
+	// IGMPProxyConfigFile is the configuration file for igmpproxy.
+	IGMPProxyConfigFile = "/run/igmpproxy.conf"
 )
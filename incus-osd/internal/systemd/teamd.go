@@ -0,0 +1,136 @@
+package systemd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// teamdConfig is the subset of the teamd JSON configuration that we generate.
+type teamdConfig struct {
+	Device    string                    `json:"device"`
+	Hwaddr    string                    `json:"hwaddr,omitempty"`
+	Runner    teamdRunner               `json:"runner"`
+	LinkWatch map[string]string         `json:"link_watch"`
+	Ports     map[string]map[string]any `json:"ports"`
+}
+
+type teamdRunner struct {
+	Name     string   `json:"name"`
+	Active   bool     `json:"active,omitempty"`
+	FastRate bool     `json:"fast_rate,omitempty"`
+	TxHash   []string `json:"tx_hash,omitempty"`
+}
+
+// applyTeamConfiguration generates a teamd configuration for each team and (re)starts the
+// corresponding daemon. Daemons for teams that no longer exist are stopped.
+func applyTeamConfiguration(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	err := os.MkdirAll(TeamdConfigPath, 0o755)
+	if err != nil {
+		return err
+	}
+
+	expectedFiles := map[string]bool{}
+
+	for _, t := range networkCfg.Teams {
+		device := "_a" + t.Name
+
+		contents, err := generateTeamdFileContents(t)
+		if err != nil {
+			return err
+		}
+
+		// Write the configuration, only restarting teamd if something changed.
+		name := device + ".conf"
+		expectedFiles[name] = true
+
+		path := filepath.Join(TeamdConfigPath, name)
+		changed := !fileContentsMatch(path, contents)
+
+		if changed {
+			// #nosec G306
+			err := os.WriteFile(path, []byte(contents), 0o644)
+			if err != nil {
+				return err
+			}
+		}
+
+		unit := "teamd@" + device + ".service"
+		if !changed && IsActive(ctx, unit) {
+			continue
+		}
+
+		err = RestartUnit(ctx, unit)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stop any teamd that is no longer needed and remove stale files.
+	entries, err := os.ReadDir(TeamdConfigPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if expectedFiles[entry.Name()] {
+			continue
+		}
+
+		device, isConfig := strings.CutSuffix(entry.Name(), ".conf")
+		if isConfig {
+			err := StopUnit(ctx, "teamd@"+device+".service")
+			if err != nil {
+				return err
+			}
+		}
+
+		err := os.Remove(filepath.Join(TeamdConfigPath, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateTeamdFileContents generates the teamd configuration for the given team.
+func generateTeamdFileContents(team api.SystemNetworkTeam) (string, error) {
+	hwaddr := team.Hwaddr
+	if hwaddr == "" {
+		hwaddr = team.Members[0]
+	}
+
+	cfg := teamdConfig{
+		Device:    "_a" + team.Name,
+		Hwaddr:    strings.ToLower(hwaddr),
+		Runner:    teamdRunner{Name: team.Mode},
+		LinkWatch: map[string]string{"name": "ethtool"},
+		Ports:     map[string]map[string]any{},
+	}
+
+	// Match the kernel bond defaults used for 802.3ad.
+	switch team.Mode {
+	case "lacp":
+		cfg.Runner.Active = true
+		cfg.Runner.FastRate = true
+		cfg.Runner.TxHash = []string{"eth", "ipv4", "ipv6", "l4"}
+	case "loadbalance":
+		cfg.Runner.TxHash = []string{"eth", "ipv4", "ipv6", "l4"}
+	}
+
+	for _, member := range team.Members {
+		cfg.Ports["_p"+strings.ToLower(strings.ReplaceAll(member, ":", ""))] = map[string]any{}
+	}
+
+	contents, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents) + "\n", nil
+}
@@ -2,6 +2,8 @@ package systemd
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/lxc/incus/v7/shared/subprocess"
 
@@ -18,3 +20,23 @@ func SetTimezone(ctx context.Context, timeCfg *api.SystemNetworkTime) error {
 
 	return err
 }
+
+// GetTimezone returns the system's current timezone.
+func GetTimezone(ctx context.Context) (string, error) {
+	output, err := subprocess.RunCommandContext(ctx, "timedatectl", "show", "--property=Timezone", "--value")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// IsValidTimezone checks if the timezone is part of the installed tzdata zone list.
+func IsValidTimezone(ctx context.Context, timezone string) (bool, error) {
+	output, err := subprocess.RunCommandContext(ctx, "timedatectl", "list-timezones", "--no-pager")
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(strings.Split(strings.TrimSpace(output), "\n"), timezone), nil
+}
@@ -0,0 +1,151 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api"
+)
+
+// applyDot1XConfiguration generates wpa_supplicant configuration for each interface with 802.1X
+// authentication configured and (re)starts the corresponding supplicant. Supplicants for interfaces
+// that no longer have 802.1X configured are stopped.
+func applyDot1XConfiguration(ctx context.Context, networkCfg *api.SystemNetworkConfig) error {
+	err := os.MkdirAll(WpaSupplicantConfigPath, 0o700)
+	if err != nil {
+		return err
+	}
+
+	expectedFiles := map[string]bool{}
+
+	for _, i := range networkCfg.Interfaces {
+		if i.Dot1X == nil {
+			continue
+		}
+
+		iface := getInterfaceDevice(i)
+
+		// Write the configuration, only restarting the supplicant if something changed.
+		changed := false
+
+		for _, cfg := range generateWpaSupplicantFileContents(iface, *i.Dot1X) {
+			expectedFiles[cfg.Name] = true
+
+			path := filepath.Join(WpaSupplicantConfigPath, cfg.Name)
+			if fileContentsMatch(path, cfg.Contents) {
+				continue
+			}
+
+			err := os.WriteFile(path, []byte(cfg.Contents), 0o600)
+			if err != nil {
+				return err
+			}
+
+			changed = true
+		}
+
+		unit := "wpa_supplicant-wired@" + iface + ".service"
+		if !changed && IsActive(ctx, unit) {
+			continue
+		}
+
+		err := RestartUnit(ctx, unit)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stop any supplicant that is no longer needed and remove stale files.
+	entries, err := os.ReadDir(WpaSupplicantConfigPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "wpa_supplicant-wired-") || expectedFiles[entry.Name()] {
+			continue
+		}
+
+		iface, isConfig := strings.CutSuffix(strings.TrimPrefix(entry.Name(), "wpa_supplicant-wired-"), ".conf")
+		if isConfig {
+			err := StopUnit(ctx, "wpa_supplicant-wired@"+iface+".service")
+			if err != nil {
+				return err
+			}
+		}
+
+		err := os.Remove(filepath.Join(WpaSupplicantConfigPath, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateWpaSupplicantFileContents generates the wpa_supplicant config file and any certificate
+// or key files needed to perform 802.1X authentication on the given interface.
+func generateWpaSupplicantFileContents(iface string, dot1x api.SystemNetworkDot1X) []NetworkdConfigFile {
+	ret := []NetworkdConfigFile{}
+
+	baseName := "wpa_supplicant-wired-" + iface
+
+	var sb strings.Builder
+
+	_, _ = sb.WriteString("ctrl_interface=/run/wpa_supplicant\n")
+	_, _ = sb.WriteString("ap_scan=0\n\n")
+	_, _ = sb.WriteString("network={\n")
+	_, _ = sb.WriteString("\tkey_mgmt=IEEE8021X\n")
+	_, _ = fmt.Fprintf(&sb, "\teap=%s\n", strings.ToUpper(dot1x.EAPMethod))
+	_, _ = fmt.Fprintf(&sb, "\tidentity=\"%s\"\n", dot1x.Identity)
+
+	if dot1x.AnonymousIdentity != "" {
+		_, _ = fmt.Fprintf(&sb, "\tanonymous_identity=\"%s\"\n", dot1x.AnonymousIdentity)
+	}
+
+	if dot1x.CACertificate != "" {
+		ret = append(ret, NetworkdConfigFile{
+			Name:     baseName + "-ca.pem",
+			Contents: dot1x.CACertificate,
+		})
+
+		_, _ = fmt.Fprintf(&sb, "\tca_cert=\"%s\"\n", filepath.Join(WpaSupplicantConfigPath, baseName+"-ca.pem"))
+	}
+
+	if dot1x.EAPMethod == "tls" {
+		ret = append(ret, NetworkdConfigFile{
+			Name:     baseName + "-cert.pem",
+			Contents: dot1x.ClientCertificate,
+		}, NetworkdConfigFile{
+			Name:     baseName + "-key.pem",
+			Contents: dot1x.ClientKey,
+		})
+
+		_, _ = fmt.Fprintf(&sb, "\tclient_cert=\"%s\"\n", filepath.Join(WpaSupplicantConfigPath, baseName+"-cert.pem"))
+		_, _ = fmt.Fprintf(&sb, "\tprivate_key=\"%s\"\n", filepath.Join(WpaSupplicantConfigPath, baseName+"-key.pem"))
+
+		if dot1x.ClientKeyPassword != "" {
+			_, _ = fmt.Fprintf(&sb, "\tprivate_key_passwd=\"%s\"\n", dot1x.ClientKeyPassword)
+		}
+	} else {
+		phase2Method := dot1x.Phase2Method
+		if phase2Method == "" {
+			phase2Method = "mschapv2"
+		}
+
+		_, _ = fmt.Fprintf(&sb, "\tpassword=\"%s\"\n", dot1x.Password)
+		_, _ = fmt.Fprintf(&sb, "\tphase2=\"auth=%s\"\n", strings.ToUpper(phase2Method))
+	}
+
+	_, _ = sb.WriteString("}\n")
+
+	ret = append(ret, NetworkdConfigFile{
+		Name:     baseName + ".conf",
+		Contents: sb.String(),
+	})
+
+	return ret
+}
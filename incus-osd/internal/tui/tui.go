@@ -393,12 +393,24 @@ func (t *TUI) getIPAddresses() []string {
 		}
 	}
 
+	for _, team := range t.state.System.Network.Config.Teams {
+		if len(team.Addresses) > 0 {
+			appendIPs(team.Name)
+		}
+	}
+
 	for _, v := range t.state.System.Network.Config.VLANs {
 		if len(v.Addresses) > 0 {
 			appendIPs(v.Name)
 		}
 	}
 
+	for _, v := range t.state.System.Network.Config.IPVLANs {
+		if len(v.Addresses) > 0 {
+			appendIPs(v.Name)
+		}
+	}
+
 	return ret
 }
 